@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package algorithm implements algorithms of the C++ <algorithm> header which the slices package does not provide.
+//
+// The functions work on any slice type, so they work on vector.Vector too:
+//
+//	vec := vector.NewWithData(3, 1, 2)
+//	algorithm.NthElement(*vec, 1, func(left int, right int) bool { return left < right })
+//	*vec = algorithm.Unique(*vec, func(left int, right int) bool { return left == right })
+//
+// The ordering functions take a comparator less, like heap.New, which must be a strict weak ordering.
+// Positions are indices, and as in the containers of this module,
+// an out-of-range position leaves the slice unmodified.
+//
+// Find, FindIf, Copy and Reverse work on iterator ranges instead, such as those of vector.Vector and list.List,
+// through the Forward and Bidirectional interfaces.
+package algorithm
+
+// MinMaxElement returns the index of the first smallest element and the index of the last largest element of s,
+// as std::minmax_element does. It returns -1, -1 if s is empty.
+func MinMaxElement[S ~[]E, E any](s S, less func(left E, right E) bool) (lo int, hi int) {
+	if len(s) == 0 {
+		return -1, -1
+	}
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[lo]) {
+			lo = i
+		}
+		if !less(s[i], s[hi]) {
+			hi = i
+		}
+	}
+	return lo, hi
+}
+
+// Unique removes all but the first element from every group of consecutive equivalent elements,
+// and returns the shortened slice. Elements between the new and the old length are zeroed.
+// Eq reports whether two elements are equivalent, it must not be nil.
+func Unique[S ~[]E, E any](s S, eq func(left E, right E) bool) S {
+	if len(s) < 2 {
+		return s
+	}
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if !eq(s[n-1], s[i]) {
+			s[n] = s[i]
+			n++
+		}
+	}
+	clear(s[n:])
+	return s[:n]
+}
+
+// reverse reverses s in place.
+func reverse[S ~[]E, E any](s S) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Rotate rotates s to the left so that s[middle] becomes the first element,
+// and returns the new index of the element that was first, which is len(s) - middle.
+// If middle < 0 or middle > len(s), s is not modified and Rotate returns -1.
+func Rotate[S ~[]E, E any](s S, middle int) int {
+	if middle < 0 || middle > len(s) {
+		return -1
+	}
+	reverse(s[:middle])
+	reverse(s[middle:])
+	reverse(s)
+	return len(s) - middle
+}
+
+// StablePartition reorders s so that the elements satisfying pred come before the others,
+// keeping the relative order inside both groups, and returns the number of elements satisfying pred.
+// It allocates a buffer for the elements not satisfying pred.
+func StablePartition[S ~[]E, E any](s S, pred func(value E) bool) int {
+	var rest []E
+	n := 0
+	for _, x := range s {
+		if pred(x) {
+			s[n] = x
+			n++
+		} else {
+			rest = append(rest, x)
+		}
+	}
+	copy(s[n:], rest)
+	return n
+}
+
+// NextPermutation rearranges s into the next lexicographically greater permutation according to less.
+// If s is the last permutation, it is rearranged into the first one, which is sorted, and NextPermutation returns false.
+func NextPermutation[S ~[]E, E any](s S, less func(left E, right E) bool) bool {
+	i := len(s) - 2
+	for i >= 0 && !less(s[i], s[i+1]) {
+		i--
+	}
+	if i < 0 {
+		reverse(s)
+		return false
+	}
+	j := len(s) - 1
+	for !less(s[i], s[j]) {
+		j--
+	}
+	s[i], s[j] = s[j], s[i]
+	reverse(s[i+1:])
+	return true
+}
+
+// PrevPermutation rearranges s into the previous lexicographically smaller permutation according to less.
+// If s is the first permutation, it is rearranged into the last one, which is sorted in descending order,
+// and PrevPermutation returns false.
+func PrevPermutation[S ~[]E, E any](s S, less func(left E, right E) bool) bool {
+	i := len(s) - 2
+	for i >= 0 && !less(s[i+1], s[i]) {
+		i--
+	}
+	if i < 0 {
+		reverse(s)
+		return false
+	}
+	j := len(s) - 1
+	for !less(s[j], s[i]) {
+		j--
+	}
+	s[i], s[j] = s[j], s[i]
+	reverse(s[i+1:])
+	return true
+}
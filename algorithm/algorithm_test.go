@@ -0,0 +1,244 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package algorithm
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/GitSteve1025/containers/list"
+	"github.com/GitSteve1025/containers/vector"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func less(left int, right int) bool {
+	return left < right
+}
+
+func equal(left int, right int) bool {
+	return left == right
+}
+
+func random(n int, limit int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = rand.Intn(limit)
+	}
+	return s
+}
+
+func TestNthElementAndPartialSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 17, 100, 1000} {
+		for _, limit := range []int{2, 1000000} {
+			s := random(n, limit)
+			sorted := slices.Sorted(slices.Values(s))
+			for _, k := range []int{0, n / 3, n - 1} {
+				if k < 0 || k >= n {
+					continue
+				}
+				x := slices.Clone(s)
+				NthElement(x, k, less)
+				same(t, x[k], sorted[k])
+				for i := range x {
+					if i < k && x[i] > x[k] || i > k && x[i] < x[k] {
+						t.Fatal("NthElement did not partition at", k)
+					}
+				}
+
+				x = slices.Clone(s)
+				PartialSort(x, k, less)
+				same(t, x[:k], sorted[:k])
+				slices.Sort(x)
+				same(t, x, sorted)
+			}
+		}
+	}
+
+	// half of the elements are equal, which unbalances the partitions.
+	s := make([]int, 5000)
+	for i := range s {
+		s[i] = i % 2 * i
+	}
+	sorted := slices.Sorted(slices.Values(s))
+	NthElement(s, 4000, less)
+	same(t, s[4000], sorted[4000])
+
+	s = []int{3, 1, 2}
+	NthElement(s, 3, less)
+	PartialSort(s, -1, less)
+	same(t, s, []int{3, 1, 2})
+}
+
+func TestStablePartitionAndMerge(t *testing.T) {
+	type pair struct{ key, order int }
+	s := make([]pair, 200)
+	for i := range s {
+		s[i] = pair{rand.Intn(10), i}
+	}
+	n := StablePartition(s, func(p pair) bool { return p.key%2 == 0 })
+	for i := range s {
+		if (i < n) != (s[i].key%2 == 0) {
+			t.Fatal("StablePartition misplaced", s[i])
+		}
+		if i != 0 && i != n && s[i-1].order > s[i].order {
+			t.Fatal("StablePartition is not stable")
+		}
+	}
+
+	byKey := func(left pair, right pair) bool { return left.key < right.key }
+	slices.SortStableFunc(s[:n], func(left pair, right pair) int { return left.key - right.key })
+	slices.SortStableFunc(s[n:], func(left pair, right pair) int { return left.key - right.key })
+	expect := slices.Clone(s)
+	slices.SortStableFunc(expect, func(left pair, right pair) int { return left.key - right.key })
+	InplaceMerge(s, n, byKey)
+	same(t, s, expect)
+
+	x := []int{1, 3, 5, 2, 4, 6}
+	InplaceMerge(x, 7, less)
+	same(t, x, []int{1, 3, 5, 2, 4, 6})
+	InplaceMerge(x, 3, less)
+	same(t, x, []int{1, 2, 3, 4, 5, 6})
+}
+
+func TestRotateAndPermutation(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4}
+	same(t, Rotate(s, 2), 3)
+	same(t, s, []int{2, 3, 4, 0, 1})
+	same(t, Rotate(s, 0), 5)
+	same(t, Rotate(s, 5), 0)
+	same(t, Rotate(s, 6), -1)
+	same(t, s, []int{2, 3, 4, 0, 1})
+
+	s = []int{1, 1, 2, 3}
+	var all [][]int
+	for {
+		all = append(all, slices.Clone(s))
+		if !NextPermutation(s, less) {
+			break
+		}
+	}
+	same(t, len(all), 12) // 4! / 2!
+	same(t, s, []int{1, 1, 2, 3})
+	same(t, all[1], []int{1, 1, 3, 2})
+	if !slices.IsSortedFunc(all, slices.Compare) {
+		t.Fatal("the permutations are not in lexicographic order")
+	}
+
+	s = []int{3, 2, 1, 1}
+	for i := len(all) - 1; i >= 0; i-- {
+		same(t, s, all[i])
+		same(t, PrevPermutation(s, less), i > 0)
+	}
+	same(t, s, []int{3, 2, 1, 1})
+	same(t, NextPermutation([]int{}, less), false)
+}
+
+func TestSet(t *testing.T) {
+	a := []int{1, 2, 2, 2, 4, 6}
+	b := []int{2, 2, 3, 6, 6, 7}
+	same(t, SetUnion(a, b, less), []int{1, 2, 2, 2, 3, 4, 6, 6, 7})
+	same(t, SetIntersection(a, b, less), []int{2, 2, 6})
+	same(t, SetDifference(a, b, less), []int{1, 2, 4})
+	same(t, SetDifference(b, a, less), []int{3, 6, 7})
+	same(t, SetUnion(a, nil, less), a)
+	same(t, len(SetIntersection(a, nil, less)), 0)
+
+	// the result has the type of the arguments.
+	union := SetUnion(*vector.NewWithData(1, 3), *vector.NewWithData(2), less)
+	same(t, union, vector.Vector[int]{1, 2, 3})
+}
+
+func TestUniqueAndMinMax(t *testing.T) {
+	vec := vector.NewWithData(1, 1, 2, 2, 2, 3, 1, 1)
+	*vec = Unique(*vec, equal)
+	same(t, *vec, vector.Vector[int]{1, 2, 3, 1})
+	same(t, len(Unique([]int{}, equal)), 0)
+
+	lo, hi := MinMaxElement([]int{3, 1, 4, 1, 5, 9, 2, 6, 9}, less)
+	same(t, lo, 1)
+	same(t, hi, 8)
+	lo, hi = MinMaxElement([]int{}, less)
+	same(t, lo, -1)
+	same(t, hi, -1)
+}
+
+func TestHeap(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 100, 1001} {
+		s := random(n, 50)
+		sorted := slices.Sorted(slices.Values(s))
+		MakeHeap(s, less)
+		same(t, IsHeap(s, less), true)
+		if n > 0 {
+			same(t, s[0], sorted[n-1])
+		}
+
+		s = append(s, 1000)
+		PushHeap(s, less)
+		same(t, s[0], 1000)
+		PopHeap(s, less)
+		same(t, s[n], 1000)
+		s = s[:n]
+		same(t, IsHeap(s, less), true)
+
+		SortHeap(s, less)
+		same(t, slices.Equal(s, sorted), true)
+	}
+	same(t, IsHeap([]int{1, 2}, less), false)
+}
+
+func TestIteratorFunctions(t *testing.T) {
+	vec := vector.NewWithData(1, 2, 3, 4, 5)
+	lst := list.NewWithData(1, 2, 3, 4, 5)
+
+	same(t, *Find(vec.Begin(), vec.End(), 3).Deref(), 3)
+	same(t, Find(vec.Begin(), vec.End(), 6), vec.End())
+	same(t, Find(lst.Begin(), lst.End(), 3).Element(), lst.Front().Next().Next())
+	same(t, Find(lst.Begin(), lst.End(), 6).Equal(lst.End()), true)
+	same(t, FindIf(vec.Begin(), vec.End(), func(x int) bool { return x > 3 }).Index(), 3)
+	same(t, *FindIf(lst.Begin(), lst.End(), func(x int) bool { return x%2 == 0 }).Deref(), 2)
+
+	Reverse(vec.Begin(), vec.End())
+	same(t, *vec, vector.Vector[int]{5, 4, 3, 2, 1})
+	Reverse(lst.Begin(), lst.End().Prev())
+	same(t, slices.Collect(lst.Values()), []int{4, 3, 2, 1, 5})
+	Reverse(lst.Begin(), lst.Begin())
+
+	// copy between containers, and in reverse order.
+	end := Copy(vec.Begin(), vec.Begin().Advance(3), lst.Begin())
+	same(t, *end.Deref(), 1)
+	same(t, slices.Collect(lst.Values()), []int{5, 4, 3, 1, 5})
+	dst := Copy(lst.Begin(), lst.End(), NewReverseRandomAccess(vec.End()))
+	same(t, *vec, vector.Vector[int]{5, 1, 3, 4, 5})
+	same(t, dst.Base(), vec.Begin())
+}
+
+func TestReverseIterator(t *testing.T) {
+	lst := list.NewWithData(1, 2, 3)
+	var values []int
+	for it := NewReverseIterator(lst.End()); !it.Equal(NewReverseIterator(lst.Begin())); it = it.Next() {
+		values = append(values, *it.Deref())
+	}
+	same(t, values, []int{3, 2, 1})
+	rbegin := NewReverseIterator(lst.End())
+	same(t, rbegin.Next().Prev(), rbegin)
+	same(t, rbegin.Base(), lst.End())
+
+	vec := vector.NewWithData(1, 2, 3, 4)
+	rbegin2, rend := NewReverseRandomAccess(vec.End()), NewReverseRandomAccess(vec.Begin())
+	same(t, rbegin2.Distance(rend), 4)
+	same(t, *rbegin2.Advance(1).Deref(), 3)
+	same(t, *rend.Prev().Deref(), 1)
+	same(t, Find(rbegin2, rend, 2).Base().Index(), 2)
+	Reverse(rbegin2, rend)
+	same(t, *vec, vector.Vector[int]{4, 3, 2, 1})
+}
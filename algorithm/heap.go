@@ -0,0 +1,71 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package algorithm
+
+// The heap functions keep a max heap according to less, as in C++: s[0] is the largest element,
+// so SortHeap sorts in ascending order. Note that heap.Heap keeps the smallest element on top instead.
+
+// siftDown moves s[i] down until it is not less than its children.
+func siftDown[S ~[]E, E any](s S, i int, less func(left E, right E) bool) {
+	for {
+		child := 2*i + 1
+		if child >= len(s) {
+			return
+		}
+		if child+1 < len(s) && less(s[child], s[child+1]) {
+			child++
+		}
+		if !less(s[i], s[child]) {
+			return
+		}
+		s[i], s[child] = s[child], s[i]
+		i = child
+	}
+}
+
+// IsHeap reports whether s is a max heap according to less.
+func IsHeap[S ~[]E, E any](s S, less func(left E, right E) bool) bool {
+	for i := 1; i < len(s); i++ {
+		if less(s[(i-1)/2], s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MakeHeap rearranges s into a max heap according to less with a time complexity of O(n).
+func MakeHeap[S ~[]E, E any](s S, less func(left E, right E) bool) {
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		siftDown(s, i, less)
+	}
+}
+
+// PushHeap inserts the last element of s into the max heap s[:len(s)-1].
+func PushHeap[S ~[]E, E any](s S, less func(left E, right E) bool) {
+	for i := len(s) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if !less(s[parent], s[i]) {
+			return
+		}
+		s[i], s[parent] = s[parent], s[i]
+		i = parent
+	}
+}
+
+// PopHeap moves the largest element of the max heap s to the end, and makes s[:len(s)-1] a max heap.
+func PopHeap[S ~[]E, E any](s S, less func(left E, right E) bool) {
+	if n := len(s) - 1; n > 0 {
+		s[0], s[n] = s[n], s[0]
+		siftDown(s[:n], 0, less)
+	}
+}
+
+// SortHeap sorts the max heap s in ascending order with a time complexity of O(n log n).
+// The sort is not stable.
+func SortHeap[S ~[]E, E any](s S, less func(left E, right E) bool) {
+	for n := len(s); n > 1; n-- {
+		PopHeap(s[:n], less)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package algorithm
+
+// The iterator functions work on the range [first, last) of any container providing iterators,
+// such as vector.Iterator and list.Iterator. I is the type of the iterator itself and T the type of the elements.
+
+// Forward is an iterator that can move forward, like a C++ forward iterator.
+type Forward[I any, T any] interface {
+	// Deref returns a reference to the element the iterator points to.
+	Deref() *T
+	// Next returns an iterator to the next element.
+	Next() I
+	// Equal returns true if both iterators point to the same position.
+	Equal(other I) bool
+}
+
+// Bidirectional is an iterator that can also move backward, like a C++ bidirectional iterator.
+type Bidirectional[I any, T any] interface {
+	Forward[I, T]
+	// Prev returns an iterator to the previous element.
+	Prev() I
+}
+
+// RandomAccess is an iterator that can move by any number of elements in constant time,
+// like a C++ random access iterator.
+type RandomAccess[I any, T any] interface {
+	Bidirectional[I, T]
+	// Advance returns an iterator n elements after the iterator, n may be negative.
+	Advance(n int) I
+	// Distance returns the number of elements from the iterator to other.
+	Distance(other I) int
+}
+
+// ReverseIterator adapts a bidirectional iterator to move in the opposite direction.
+// As std::reverse_iterator, it points to the element before its base,
+// so NewReverseIterator(end) points to the last element and NewReverseIterator(begin) is the reverse end.
+type ReverseIterator[I Bidirectional[I, T], T any] struct {
+	base I
+}
+
+// NewReverseIterator returns a reverse iterator pointing to the element before base.
+func NewReverseIterator[I Bidirectional[I, T], T any](base I) ReverseIterator[I, T] {
+	return ReverseIterator[I, T]{base}
+}
+
+// Base returns the underlying iterator, which points to the element after the one the reverse iterator points to.
+func (it ReverseIterator[I, T]) Base() I {
+	return it.base
+}
+
+// Deref returns a reference to the element before the base.
+func (it ReverseIterator[I, T]) Deref() *T {
+	return it.base.Prev().Deref()
+}
+
+// Next returns a reverse iterator to the previous element of the underlying sequence.
+func (it ReverseIterator[I, T]) Next() ReverseIterator[I, T] {
+	return ReverseIterator[I, T]{it.base.Prev()}
+}
+
+// Prev returns a reverse iterator to the next element of the underlying sequence.
+func (it ReverseIterator[I, T]) Prev() ReverseIterator[I, T] {
+	return ReverseIterator[I, T]{it.base.Next()}
+}
+
+// Equal returns true if both reverse iterators have the same base.
+func (it ReverseIterator[I, T]) Equal(other ReverseIterator[I, T]) bool {
+	return it.base.Equal(other.base)
+}
+
+// ReverseRandomAccess is the ReverseIterator of a random access iterator, it is a random access iterator too.
+type ReverseRandomAccess[I RandomAccess[I, T], T any] struct {
+	base I
+}
+
+// NewReverseRandomAccess returns a reverse random access iterator pointing to the element before base.
+func NewReverseRandomAccess[I RandomAccess[I, T], T any](base I) ReverseRandomAccess[I, T] {
+	return ReverseRandomAccess[I, T]{base}
+}
+
+// Base returns the underlying iterator, which points to the element after the one the reverse iterator points to.
+func (it ReverseRandomAccess[I, T]) Base() I {
+	return it.base
+}
+
+// Deref returns a reference to the element before the base.
+func (it ReverseRandomAccess[I, T]) Deref() *T {
+	return it.base.Prev().Deref()
+}
+
+// Next returns a reverse iterator to the previous element of the underlying sequence.
+func (it ReverseRandomAccess[I, T]) Next() ReverseRandomAccess[I, T] {
+	return ReverseRandomAccess[I, T]{it.base.Prev()}
+}
+
+// Prev returns a reverse iterator to the next element of the underlying sequence.
+func (it ReverseRandomAccess[I, T]) Prev() ReverseRandomAccess[I, T] {
+	return ReverseRandomAccess[I, T]{it.base.Next()}
+}
+
+// Advance returns a reverse iterator n elements further in the reverse direction.
+func (it ReverseRandomAccess[I, T]) Advance(n int) ReverseRandomAccess[I, T] {
+	return ReverseRandomAccess[I, T]{it.base.Advance(-n)}
+}
+
+// Distance returns the number of elements from it to other in the reverse direction.
+func (it ReverseRandomAccess[I, T]) Distance(other ReverseRandomAccess[I, T]) int {
+	return other.base.Distance(it.base)
+}
+
+// Equal returns true if both reverse iterators have the same base.
+func (it ReverseRandomAccess[I, T]) Equal(other ReverseRandomAccess[I, T]) bool {
+	return it.base.Equal(other.base)
+}
+
+// Find returns an iterator to the first element of [first, last) equal to value, or last if there is none.
+func Find[I Forward[I, T], T comparable](first I, last I, value T) I {
+	for ; !first.Equal(last); first = first.Next() {
+		if *first.Deref() == value {
+			return first
+		}
+	}
+	return last
+}
+
+// FindIf returns an iterator to the first element of [first, last) satisfying pred, or last if there is none.
+func FindIf[I Forward[I, T], T any](first I, last I, pred func(value T) bool) I {
+	for ; !first.Equal(last); first = first.Next() {
+		if pred(*first.Deref()) {
+			return first
+		}
+	}
+	return last
+}
+
+// Copy copies the elements of [first, last) to the range starting at dst, and returns an iterator past the last copied element.
+// The destination must have room for the elements, it may overlap [first, last) only if it starts before first.
+func Copy[I Forward[I, T], O Forward[O, T], T any](first I, last I, dst O) O {
+	for ; !first.Equal(last); first, dst = first.Next(), dst.Next() {
+		*dst.Deref() = *first.Deref()
+	}
+	return dst
+}
+
+// Reverse reverses the order of the elements of [first, last) by swapping their values.
+func Reverse[I Bidirectional[I, T], T any](first I, last I) {
+	for !first.Equal(last) {
+		last = last.Prev()
+		if first.Equal(last) {
+			return
+		}
+		x, y := first.Deref(), last.Deref()
+		*x, *y = *y, *x
+		first = first.Next()
+	}
+}
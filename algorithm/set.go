@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package algorithm
+
+// The set functions take two ranges sorted according to less and return a new sorted slice.
+// As in C++, the ranges may hold equivalent elements: an element present m times in a and n times in b
+// is present max(m, n) times in the union, min(m, n) times in the intersection
+// and max(m-n, 0) times in the difference. The elements of a are preferred.
+
+// SetUnion returns the elements present in a or b.
+func SetUnion[S ~[]E, E any](a S, b S, less func(left E, right E) bool) S {
+	result := make(S, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			result = append(result, b[j])
+			j++
+		} else {
+			if !less(a[i], b[j]) {
+				j++
+			}
+			result = append(result, a[i])
+			i++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+// SetIntersection returns the elements present in both a and b.
+func SetIntersection[S ~[]E, E any](a S, b S, less func(left E, right E) bool) S {
+	var result S
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case less(a[i], b[j]):
+			i++
+		case less(b[j], a[i]):
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// SetDifference returns the elements present in a but not in b.
+func SetDifference[S ~[]E, E any](a S, b S, less func(left E, right E) bool) S {
+	var result S
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case less(a[i], b[j]):
+			result = append(result, a[i])
+			i++
+		case less(b[j], a[i]):
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return append(result, a[i:]...)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package algorithm
+
+import "math/bits"
+
+// insertionSort sorts s in ascending order, it is used for short ranges.
+func insertionSort[S ~[]E, E any](s S, less func(left E, right E) bool) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && less(s[j], s[j-1]); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// partition partitions s around the median of its first, middle and last elements,
+// and returns the final index p of the pivot: s[:p] are not greater and s[p+1:] are not less than s[p].
+func partition[S ~[]E, E any](s S, less func(left E, right E) bool) int {
+	a, b, c := 0, len(s)/2, len(s)-1
+	if less(s[b], s[a]) {
+		a, b = b, a
+	}
+	if less(s[c], s[b]) {
+		b = c
+		if less(s[b], s[a]) {
+			b = a
+		}
+	}
+	s[0], s[b] = s[b], s[0]
+
+	p := 0
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[0]) {
+			p++
+			s[p], s[i] = s[i], s[p]
+		}
+	}
+	s[0], s[p] = s[p], s[0]
+	return p
+}
+
+// NthElement rearranges s so that s[n] is the element which would be there if s were sorted,
+// the elements before it are not greater and the elements after it are not less.
+// The time complexity is O(n) on average and O(n log n) in the worst case.
+// If n is out of range, s is not modified.
+func NthElement[S ~[]E, E any](s S, n int, less func(left E, right E) bool) {
+	if n < 0 || n >= len(s) {
+		return
+	}
+	// quickselect, falling back to heapsort when the partitions are too unbalanced.
+	limit := 2 * bits.Len(uint(len(s)))
+	for len(s) > 16 {
+		if limit == 0 {
+			MakeHeap(s, less)
+			SortHeap(s, less)
+			return
+		}
+		limit--
+		p := partition(s, less)
+		switch {
+		case n == p:
+			return
+		case n < p:
+			s = s[:p]
+		default:
+			s, n = s[p+1:], n-p-1
+		}
+	}
+	insertionSort(s, less)
+}
+
+// PartialSort rearranges s so that s[:middle] holds the middle smallest elements in ascending order.
+// The order of the other elements is unspecified. The time complexity is O(n log middle), the sort is not stable.
+// If middle < 0 or middle > len(s), s is not modified.
+func PartialSort[S ~[]E, E any](s S, middle int, less func(left E, right E) bool) {
+	if middle < 0 || middle > len(s) {
+		return
+	}
+	head := s[:middle]
+	MakeHeap(head, less)
+	for i := middle; i < len(s); i++ {
+		if middle > 0 && less(s[i], head[0]) {
+			head[0], s[i] = s[i], head[0]
+			siftDown(head, 0, less)
+		}
+	}
+	SortHeap(head, less)
+}
+
+// InplaceMerge merges the sorted s[:middle] and s[middle:] into the sorted s.
+// The merge is stable: elements of s[:middle] come before the equivalent elements of s[middle:].
+// It allocates a buffer for s[:middle].
+// If middle < 0 or middle > len(s), s is not modified.
+func InplaceMerge[S ~[]E, E any](s S, middle int, less func(left E, right E) bool) {
+	if middle <= 0 || middle >= len(s) || !less(s[middle], s[middle-1]) {
+		return
+	}
+	left := append([]E(nil), s[:middle]...)
+	i, j, k := 0, middle, 0
+	for i < len(left) && j < len(s) {
+		if less(s[j], left[i]) {
+			s[k] = s[j]
+			j++
+		} else {
+			s[k] = left[i]
+			i++
+		}
+		k++
+	}
+	copy(s[k:], left[i:])
+}
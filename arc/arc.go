@@ -0,0 +1,229 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package arc implements an adaptive replacement cache (ARC) on top of list.List,
+// as described by Megiddo and Modha in "ARC: A Self-Tuning, Low Overhead Replacement Cache".
+//
+// The cache keeps two lists of entries: t1 holds the keys seen once recently, and t2 the keys seen
+// at least twice. Two ghost lists, b1 and b2, remember the keys recently evicted from t1 and t2
+// without their values. A hit in a ghost list tells which of recency or frequency would have kept
+// the key, and moves the target size of t1 accordingly. A scan of cold keys only goes through t1,
+// so the frequently used keys of t2 survive it, unlike in an LRU cache.
+// All operations are O(1).
+package arc
+
+import (
+	"iter"
+
+	"github.com/GitSteve1025/containers/list"
+)
+
+// the lists of the cache.
+const (
+	t1 = iota
+	t2
+	b1
+	b2
+)
+
+// entry is an entry of the cache, the value of a ghost entry is not kept.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	// the list holding the entry.
+	list int
+}
+
+// Cache is an adaptive replacement cache.
+// A Cache is not safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	// t1, t2, b1 and b2, each from the most to the least recently used.
+	lists    [4]*list.List[entry[K, V]]
+	items    map[K]*list.Element[entry[K, V]]
+	capacity int
+	// the target size of t1.
+	target  int
+	onEvict func(key K, value V)
+}
+
+// New creates an empty Cache[K, V] which holds at most capacity entries,
+// and remembers at most capacity evicted keys.
+// New panics if capacity is not positive.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("arc: capacity must be positive")
+	}
+	cache := &Cache[K, V]{
+		items:    make(map[K]*list.Element[entry[K, V]]),
+		capacity: capacity,
+	}
+	for i := range cache.lists {
+		cache.lists[i] = list.New[entry[K, V]]()
+	}
+	return cache
+}
+
+// SetOnEvict sets a function which is called with every entry evicted because the cache is full.
+// A nil onEvict removes the function.
+// Entries removed by Remove, Clear or replaced by Put are not reported.
+func (cache *Cache[K, V]) SetOnEvict(onEvict func(key K, value V)) {
+	cache.onEvict = onEvict
+}
+
+// Size returns the number of entries in the cache, the evicted keys are not counted.
+func (cache *Cache[K, V]) Size() int {
+	return cache.lists[t1].Size() + cache.lists[t2].Size()
+}
+
+// Capacity returns the maximum number of entries.
+func (cache *Cache[K, V]) Capacity() int {
+	return cache.capacity
+}
+
+// resident returns the element of key if the cache contains key, otherwise nil.
+func (cache *Cache[K, V]) resident(key K) *list.Element[entry[K, V]] {
+	if e, ok := cache.items[key]; ok && e.Value.list <= t2 {
+		return e
+	}
+	return nil
+}
+
+// move moves e to the front of list to.
+func (cache *Cache[K, V]) move(e *list.Element[entry[K, V]], to int) {
+	target := cache.lists[to]
+	target.SpliceElement(target.Front(), cache.lists[e.Value.list], e)
+	e.Value.list = to
+}
+
+// drop removes e from the cache.
+func (cache *Cache[K, V]) drop(e *list.Element[entry[K, V]]) {
+	cache.lists[e.Value.list].Erase(e)
+	delete(cache.items, e.Value.key)
+}
+
+// evict evicts the value of e, whose key is remembered by ghost, and reports it to onEvict.
+func (cache *Cache[K, V]) evict(e *list.Element[entry[K, V]], ghost int) {
+	value := e.Value.value
+	var zero V
+	e.Value.value = zero // avoid memory leaks
+	cache.move(e, ghost)
+	if cache.onEvict != nil {
+		cache.onEvict(e.Value.key, value)
+	}
+}
+
+// replace evicts the least recently used entry of t1 or t2, depending on the target size of t1,
+// if the cache is full.
+func (cache *Cache[K, V]) replace(inB2 bool) {
+	if cache.Size() < cache.capacity {
+		return
+	}
+	size := cache.lists[t1].Size()
+	if size > 0 && (size > cache.target || (inB2 && size == cache.target) || cache.lists[t2].Empty()) {
+		cache.evict(cache.lists[t1].Back(), b1)
+	} else {
+		cache.evict(cache.lists[t2].Back(), b2)
+	}
+}
+
+// Get returns the value of key and true, and moves the entry to the front of t2.
+// If the cache does not contain key, Get will return the default value of V and false.
+func (cache *Cache[K, V]) Get(key K) (value V, ok bool) {
+	if e := cache.resident(key); e != nil {
+		cache.move(e, t2)
+		return e.Value.value, true
+	}
+	return
+}
+
+// Peek returns the value of key and true, without recording the access.
+// If the cache does not contain key, Peek will return the default value of V and false.
+func (cache *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	if e := cache.resident(key); e != nil {
+		return e.Value.value, true
+	}
+	return
+}
+
+// Contains returns true if the cache contains key, without recording the access.
+func (cache *Cache[K, V]) Contains(key K) bool {
+	return cache.resident(key) != nil
+}
+
+// Put puts key with value.
+// A key which is in the cache or has been evicted recently goes to the front of t2, a new key goes to the front of t1.
+// If the cache is full, an entry is evicted first.
+func (cache *Cache[K, V]) Put(key K, value V) {
+	if e, ok := cache.items[key]; ok {
+		switch e.Value.list {
+		case b1:
+			// recency would have kept key, t1 should grow.
+			delta := max(1, cache.lists[b2].Size()/cache.lists[b1].Size())
+			cache.target = min(cache.capacity, cache.target+delta)
+			cache.replace(false)
+		case b2:
+			// frequency would have kept key, t2 should grow.
+			delta := max(1, cache.lists[b1].Size()/cache.lists[b2].Size())
+			cache.target = max(0, cache.target-delta)
+			cache.replace(true)
+		}
+		e.Value.value = value
+		cache.move(e, t2)
+		return
+	}
+
+	if size := cache.lists[t1].Size() + cache.lists[b1].Size(); size >= cache.capacity {
+		if cache.lists[t1].Size() < cache.capacity {
+			cache.drop(cache.lists[b1].Back())
+			cache.replace(false)
+		} else {
+			victim := cache.lists[t1].Back()
+			cache.drop(victim)
+			if cache.onEvict != nil {
+				cache.onEvict(victim.Value.key, victim.Value.value)
+			}
+		}
+	} else if total := size + cache.lists[t2].Size() + cache.lists[b2].Size(); total >= cache.capacity {
+		if total >= 2*cache.capacity {
+			cache.drop(cache.lists[b2].Back())
+		}
+		cache.replace(false)
+	}
+	cache.items[key] = cache.lists[t1].PushFront(entry[K, V]{key: key, value: value, list: t1})
+}
+
+// Remove removes key from the cache and returns true.
+// If the cache does not contain key, the cache is not modified and Remove returns false.
+// An evicted key is forgotten, but Remove returns false.
+func (cache *Cache[K, V]) Remove(key K) bool {
+	if e, ok := cache.items[key]; ok {
+		cache.drop(e)
+		return e.Value.list <= t2
+	}
+	return false
+}
+
+// Clear removes all entries of the cache and forgets the evicted keys.
+func (cache *Cache[K, V]) Clear() {
+	for _, l := range cache.lists {
+		l.Clear()
+	}
+	clear(cache.items)
+	cache.target = 0
+}
+
+// All returns an iterator over the key-value pairs of the cache, those of t2 first,
+// each list from the most to the least recently used. The accesses are not recorded.
+// The cache must not be modified during the iteration.
+func (cache *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, i := range []int{t2, t1} {
+			for e := cache.lists[i].Front(); e != nil; e = e.Next() {
+				if !yield(e.Value.key, e.Value.value) {
+					return
+				}
+			}
+		}
+	}
+}
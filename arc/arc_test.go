@@ -0,0 +1,154 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package arc
+
+import (
+	"maps"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+// verify checks the bounds of the lists and that they hold every entry of the map.
+func verify[K comparable, V any](t *testing.T, cache *Cache[K, V]) {
+	t.Helper()
+	count := 0
+	for i, l := range cache.lists {
+		for e := l.Front(); e != nil; e = e.Next() {
+			if e.Value.list != i || cache.items[e.Value.key] != e {
+				t.Fatal("entry", e.Value.key, "is invalid")
+			}
+			count++
+		}
+	}
+	same(t, count, len(cache.items))
+	size := func(i int) int {
+		return cache.lists[i].Size()
+	}
+	if size(t1)+size(t2) > cache.capacity || size(t1)+size(b1) > cache.capacity || count > 2*cache.capacity {
+		t.Fatal("the lists are too long", size(t1), size(t2), size(b1), size(b2))
+	}
+	if cache.target < 0 || cache.target > cache.capacity {
+		t.Fatal("the target is out of range", cache.target)
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := New[string, int](2)
+	var evicted []string
+	cache.SetOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	value, ok := cache.Get("a") // a goes to t2
+	same(t, value, 1)
+	same(t, ok, true)
+	cache.Put("c", 3) // b is evicted from t1
+	same(t, evicted, []string{"b"})
+	value, ok = cache.Peek("c")
+	same(t, value, 3)
+	same(t, ok, true)
+	same(t, cache.Contains("b"), false)
+	_, ok = cache.Get("b")
+	same(t, ok, false)
+	verify(t, cache)
+
+	cache.Put("b", 20) // a ghost hit in b1, b goes to t2 and t1 grows at the expense of t2
+	same(t, evicted, []string{"b", "a"})
+	same(t, cache.target, 1)
+	same(t, maps.Collect(cache.All()), map[string]int{"b": 20, "c": 3})
+	cache.Put("a", 10) // a ghost hit in b2, t2 grows at the expense of t1
+	same(t, evicted, []string{"b", "a", "c"})
+	same(t, cache.target, 0)
+	same(t, maps.Collect(cache.All()), map[string]int{"a": 10, "b": 20})
+	cache.Put("a", 1) // replaced, not evicted
+	same(t, len(evicted), 3)
+	same(t, cache.Size(), 2)
+	same(t, cache.Capacity(), 2)
+	verify(t, cache)
+
+	same(t, cache.Remove("c"), false) // forgotten
+	same(t, cache.Remove("a"), true)
+	same(t, cache.Remove("a"), false)
+	verify(t, cache)
+	cache.Clear()
+	same(t, cache.Size(), 0)
+	same(t, len(cache.items), 0)
+	verify(t, cache)
+}
+
+func TestScanResistance(t *testing.T) {
+	cache := New[int, int](100)
+	// make 0..49 frequent.
+	for round := 0; round < 3; round++ {
+		for k := 0; k < 50; k++ {
+			if _, ok := cache.Get(k); !ok {
+				cache.Put(k, k)
+			}
+		}
+	}
+	// a long scan of cold keys.
+	for k := 1000; k < 2000; k++ {
+		cache.Put(k, k)
+	}
+	for k := 0; k < 50; k++ {
+		if !cache.Contains(k) {
+			t.Fatal("the scan evicted", k)
+		}
+	}
+	verify(t, cache)
+}
+
+func TestCacheRandom(t *testing.T) {
+	cache := New[int, int](50)
+	resident := make(map[int]bool)
+	cache.SetOnEvict(func(key int, value int) {
+		same(t, key, value)
+		delete(resident, key)
+	})
+	for i := 0; i < 50000; i++ {
+		k := int(rand.ExpFloat64() * 60)
+		if rand.Intn(50) == 0 {
+			k = 1000 + i // a cold key
+		}
+		switch rand.Intn(10) {
+		case 0:
+			same(t, cache.Remove(k), resident[k])
+			delete(resident, k)
+		case 1, 2, 3:
+			cache.Put(k, k)
+			resident[k] = true
+		default:
+			value, ok := cache.Get(k)
+			same(t, ok, resident[k])
+			if ok {
+				same(t, value, k)
+			}
+		}
+		if i%1000 == 0 {
+			verify(t, cache)
+		}
+	}
+	verify(t, cache)
+	same(t, cache.Size(), len(resident))
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New with capacity 0 must panic")
+		}
+	}()
+	New[int, int](0)
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package btree implements ordered maps and sets backed by an in-memory B-tree.
+//
+// A B-tree stores many keys per node in contiguous memory, so it needs fewer pointers and
+// fewer cache misses than a red-black tree, which makes it a better fit for millions of small keys.
+// Get, Insert and Erase have a time complexity of O(log n).
+//
+// Clone is O(1): the nodes are shared and copied lazily by the first tree that modifies them.
+//
+// Keys are ordered by a comparator less(left, right) which reports whether left is ordered before right,
+// two keys are equivalent when neither is less than the other.
+package btree
+
+import "iter"
+
+// DefaultDegree is a reasonable degree for small keys such as int64.
+const DefaultDegree = 32
+
+// Map is an ordered map with unique keys backed by a B-tree.
+// A Map is not safe for concurrent use, but clones may be used by different goroutines.
+type Map[K any, V any] struct {
+	// every node other than the root holds between degree-1 and 2*degree-1 items.
+	degree int
+	less   func(left K, right K) bool
+	root   *node[K, V]
+	size   int
+	cow    *copyOnWrite
+}
+
+// New creates an empty Map[K, V] ordered by comparator, whose nodes hold at most 2*degree-1 keys.
+// New panics if degree < 2.
+// Comparator must not be nil.
+func New[K any, V any](degree int, comparator func(left K, right K) bool) *Map[K, V] {
+	if degree < 2 {
+		panic("btree: degree must be at least 2")
+	}
+	return &Map[K, V]{
+		degree: degree,
+		less:   comparator,
+		cow:    new(copyOnWrite),
+	}
+}
+
+// FromSorted creates a Map[K, V] which contains the key-value pairs of seq in O(n) time.
+// The keys must be yielded in strictly ascending order according to comparator, otherwise FromSorted panics.
+// The nodes are filled evenly, which makes the map compact and fast to read.
+// FromSorted panics if degree < 2.
+// Comparator must not be nil.
+func FromSorted[K any, V any](degree int, comparator func(left K, right K) bool, seq iter.Seq2[K, V]) *Map[K, V] {
+	m := New[K, V](degree, comparator)
+	var items []item[K, V]
+	for k, v := range seq {
+		if len(items) > 0 && !comparator(items[len(items)-1].key, k) {
+			panic("btree: keys are not sorted in strictly ascending order")
+		}
+		items = append(items, item[K, V]{key: k, value: v})
+	}
+	if len(items) == 0 {
+		return m
+	}
+
+	// find the height of the smallest tree which can hold all items.
+	height := 1
+	for capacity := m.maxItems(); capacity < len(items); height++ {
+		capacity = (capacity+1)*(m.maxItems()+1) - 1
+	}
+	m.root = m.build(items, height)
+	m.size = len(items)
+	return m
+}
+
+// build creates a subtree of the given height holding items.
+func (m *Map[K, V]) build(items []item[K, V], height int) *node[K, V] {
+	n := &node[K, V]{cow: m.cow}
+	if height == 1 {
+		n.items = append(make([]item[K, V], 0, m.maxItems()), items...)
+		return n
+	}
+
+	// capacity of a child subtree.
+	capacity := m.maxItems()
+	for h := 2; h < height; h++ {
+		capacity = (capacity+1)*(m.maxItems()+1) - 1
+	}
+	// use as few children as possible, but at least two.
+	children := max(2, (len(items)+1+capacity)/(capacity+1))
+	n.items = make([]item[K, V], 0, m.maxItems())
+	n.children = make([]*node[K, V], 0, m.maxItems()+1)
+
+	// the items which are not separators are spread evenly over the children.
+	rest := len(items) - (children - 1)
+	for i := 0; i < children; i++ {
+		count := rest / children
+		if i < rest%children {
+			count++
+		}
+		n.children = append(n.children, m.build(items[:count], height-1))
+		items = items[count:]
+		if i < children-1 {
+			n.items = append(n.items, items[0])
+			items = items[1:]
+		}
+	}
+	return n
+}
+
+// maxItems returns the maximum number of items in a node.
+func (m *Map[K, V]) maxItems() int {
+	return m.degree*2 - 1
+}
+
+// minItems returns the minimum number of items in a node other than the root.
+func (m *Map[K, V]) minItems() int {
+	return m.degree - 1
+}
+
+// Degree returns the degree of the B-tree.
+func (m *Map[K, V]) Degree() int {
+	return m.degree
+}
+
+// Size returns the number of elements in the map.
+func (m *Map[K, V]) Size() int {
+	return m.size
+}
+
+// Empty returns true if the map is empty.
+func (m *Map[K, V]) Empty() bool {
+	return m.size == 0
+}
+
+// Clear removes all elements of the map.
+// The nodes shared with clones are left untouched.
+func (m *Map[K, V]) Clear() {
+	m.root = nil
+	m.size = 0
+}
+
+// Clone returns a copy of the map in O(1) time.
+// The map and its clone share their nodes until one of them modifies a node, which is then copied.
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	// both maps get a new identity, so that neither of them modifies the shared nodes in place.
+	out := *m
+	out.cow = new(copyOnWrite)
+	m.cow = new(copyOnWrite)
+	return &out
+}
+
+// Get returns the value mapped to key and true.
+// If the map does not contain key, Get will return the default value of V and false.
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	for n := m.root; n != nil; {
+		i, found := n.find(key, m.less)
+		if found {
+			return n.items[i].value, true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return
+}
+
+// Contains returns true if the map contains key.
+func (m *Map[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Front returns the smallest key and its value.
+// If the map is empty, Front will return the default values of K and V and false.
+func (m *Map[K, V]) Front() (key K, value V, ok bool) {
+	n := m.root
+	if n == nil {
+		return
+	}
+	for len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n.items[0].key, n.items[0].value, true
+}
+
+// Back returns the largest key and its value.
+// If the map is empty, Back will return the default values of K and V and false.
+func (m *Map[K, V]) Back() (key K, value V, ok bool) {
+	n := m.root
+	if n == nil {
+		return
+	}
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	last := n.items[len(n.items)-1]
+	return last.key, last.value, true
+}
+
+// insert inserts key with value and returns true.
+// If the map already contains key, its value is replaced when replace is true, and insert returns false.
+func (m *Map[K, V]) insert(key K, value V, replace bool) bool {
+	it := item[K, V]{key: key, value: value}
+	if m.root == nil {
+		m.root = &node[K, V]{cow: m.cow}
+		m.root.items = append(make([]item[K, V], 0, m.maxItems()), it)
+		m.size++
+		return true
+	}
+
+	m.root = m.root.mutableFor(m.cow)
+	if len(m.root.items) >= m.maxItems() {
+		// split the full root, the tree grows by one level.
+		middle, second := m.root.split(m.maxItems() / 2)
+		first := m.root
+		m.root = &node[K, V]{cow: m.cow}
+		m.root.items = append(make([]item[K, V], 0, m.maxItems()), middle)
+		m.root.children = append(make([]*node[K, V], 0, m.maxItems()+1), first, second)
+	}
+	if m.root.insert(it, m.maxItems(), replace, m.less) {
+		m.size++
+		return true
+	}
+	return false
+}
+
+// Insert inserts key with value and returns true.
+// If the map already contains key, the map is not modified and Insert returns false.
+func (m *Map[K, V]) Insert(key K, value V) bool {
+	if m.Contains(key) {
+		return false
+	}
+	return m.insert(key, value, false)
+}
+
+// InsertOrAssign inserts key with value and returns true.
+// If the map already contains key, its value is replaced and InsertOrAssign returns false.
+func (m *Map[K, V]) InsertOrAssign(key K, value V) bool {
+	return m.insert(key, value, true)
+}
+
+// remove removes an item and returns it.
+func (m *Map[K, V]) remove(key K, kind removeKind) (removed item[K, V], ok bool) {
+	if m.root == nil {
+		return
+	}
+	m.root = m.root.mutableFor(m.cow)
+	removed, ok = m.root.remove(key, m.minItems(), kind, m.less)
+	if len(m.root.items) == 0 {
+		// the tree shrinks by one level.
+		if len(m.root.children) > 0 {
+			m.root = m.root.children[0]
+		} else {
+			m.root = nil
+		}
+	}
+	if ok {
+		m.size--
+	}
+	return
+}
+
+// Erase removes key from the map and returns true.
+// If the map does not contain key, the map is not modified and Erase returns false.
+func (m *Map[K, V]) Erase(key K) bool {
+	if !m.Contains(key) {
+		return false
+	}
+	_, ok := m.remove(key, removeKey)
+	return ok
+}
+
+// PopFront removes the smallest key and returns it with its value.
+// If the map is empty, PopFront will return the default values of K and V and false.
+func (m *Map[K, V]) PopFront() (key K, value V, ok bool) {
+	var zero K
+	removed, ok := m.remove(zero, removeMin)
+	return removed.key, removed.value, ok
+}
+
+// PopBack removes the largest key and returns it with its value.
+// If the map is empty, PopBack will return the default values of K and V and false.
+func (m *Map[K, V]) PopBack() (key K, value V, ok bool) {
+	var zero K
+	removed, ok := m.remove(zero, removeMax)
+	return removed.key, removed.value, ok
+}
+
+// All returns an iterator over key-value pairs of the map in ascending key order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.ascend(nil, nil, m.less, yield)
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs of the map in descending key order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.descend(yield)
+		}
+	}
+}
+
+// Range returns an iterator over key-value pairs of the map whose keys are in [lo, hi), in ascending key order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) Range(lo K, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil && m.less(lo, hi) {
+			m.root.ascend(&lo, &hi, m.less, yield)
+		}
+	}
+}
+
+// From returns an iterator over key-value pairs of the map whose keys are not less than lo, in ascending key order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) From(lo K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.ascend(&lo, nil, m.less, yield)
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map in ascending order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package btree
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/GitSteve1025/containers/vector"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func less(a int, b int) bool {
+	return a < b
+}
+
+// verify checks the B-tree properties: item counts, key order and the depth of the leaves.
+func verify[K any, V any](t *testing.T, m *Map[K, V]) {
+	t.Helper()
+	if m.root == nil {
+		same(t, m.size, 0)
+		return
+	}
+	leafDepth := -1
+	var walk func(n *node[K, V], depth int, lo *K, hi *K) int
+	walk = func(n *node[K, V], depth int, lo *K, hi *K) int {
+		if len(n.items) > m.maxItems() || (n != m.root && len(n.items) < m.minItems()) || len(n.items) == 0 {
+			t.Fatal("node has", len(n.items), "items")
+		}
+		for i, it := range n.items {
+			if (i > 0 && !m.less(n.items[i-1].key, it.key)) || (lo != nil && !m.less(*lo, it.key)) || (hi != nil && !m.less(it.key, *hi)) {
+				t.Fatal("keys are not in order")
+			}
+		}
+		if len(n.children) == 0 {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			if leafDepth != depth {
+				t.Fatal("leaves are not at the same depth")
+			}
+			return len(n.items)
+		}
+		if len(n.children) != len(n.items)+1 {
+			t.Fatal("node has", len(n.children), "children and", len(n.items), "items")
+		}
+		size := len(n.items)
+		for i, child := range n.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &n.items[i-1].key
+			}
+			if i < len(n.items) {
+				childHi = &n.items[i].key
+			}
+			size += walk(child, depth+1, childLo, childHi)
+		}
+		return size
+	}
+	same(t, walk(m.root, 0, nil, nil), m.size)
+}
+
+func TestMapBasicFunction(t *testing.T) {
+	m := New[int, string](2, less)
+	same(t, m.Empty(), true)
+	_, _, ok := m.Front()
+	same(t, ok, false)
+	_, _, ok = m.PopBack()
+	same(t, ok, false)
+	same(t, m.Erase(1), false)
+
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		same(t, m.Insert(k, fmt.Sprint(k)), true)
+	}
+	same(t, m.Insert(5, "x"), false)
+	value, ok := m.Get(5)
+	same(t, value, "5") // not modified
+	same(t, ok, true)
+	same(t, m.InsertOrAssign(5, "five"), false)
+	value, _ = m.Get(5)
+	same(t, value, "five")
+	same(t, m.Contains(10), false)
+	same(t, m.Size(), 9)
+	verify(t, m)
+
+	key, value, ok := m.Front()
+	same(t, []any{key, value, ok}, []any{1, "1", true})
+	key, value, ok = m.Back()
+	same(t, []any{key, value, ok}, []any{9, "9", true})
+
+	same(t, slices.Collect(m.Keys()), []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	var keys []int
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	same(t, keys, []int{9, 8, 7, 6, 5, 4, 3, 2, 1})
+	keys = keys[:0]
+	for k := range m.Range(3, 7) {
+		keys = append(keys, k)
+	}
+	same(t, keys, []int{3, 4, 5, 6})
+	keys = keys[:0]
+	for k := range m.From(7) {
+		keys = append(keys, k)
+	}
+	same(t, keys, []int{7, 8, 9})
+
+	key, _, _ = m.PopFront()
+	same(t, key, 1)
+	key, _, _ = m.PopBack()
+	same(t, key, 9)
+	same(t, m.Erase(5), true)
+	same(t, m.Erase(5), false)
+	same(t, slices.Collect(m.Keys()), []int{2, 3, 4, 6, 7, 8})
+	verify(t, m)
+
+	m.Clear()
+	same(t, m.Empty(), true)
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New with degree 1 must panic")
+		}
+	}()
+	New[int, int](1, less)
+}
+
+func TestMapRandom(t *testing.T) {
+	for _, degree := range []int{2, 3, 8, 32} {
+		const N = 50000
+		m := New[int, int](degree, less)
+		expect := make(map[int]int)
+		for i := 0; i < N; i++ {
+			k := rand.Intn(N / 5)
+			switch rand.Intn(4) {
+			case 0:
+				_, ok := expect[k]
+				same(t, m.Erase(k), ok)
+				delete(expect, k)
+			case 1:
+				if key, value, ok := m.PopFront(); ok {
+					same(t, value, expect[key])
+					delete(expect, key)
+				}
+			default:
+				_, ok := expect[k]
+				same(t, m.InsertOrAssign(k, i), !ok)
+				expect[k] = i
+			}
+		}
+		verify(t, m)
+		same(t, m.Size(), len(expect))
+		same(t, slices.Collect(m.Keys()), slices.Sorted(maps.Keys(expect)))
+		for k, v := range m.All() {
+			same(t, v, expect[k])
+		}
+		for k, v := range expect {
+			value, ok := m.Get(k)
+			same(t, ok, true)
+			same(t, value, v)
+		}
+	}
+}
+
+func TestFromSorted(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 16} {
+		for n := 0; n < 300; n++ {
+			keys := make([]int, n)
+			for i := range keys {
+				keys[i] = i * 2
+			}
+			m := FromSorted(degree, less, func(yield func(int, int) bool) {
+				for _, k := range keys {
+					if !yield(k, -k) {
+						return
+					}
+				}
+			})
+			verify(t, m)
+			same(t, m.Size(), n)
+			if n > 0 {
+				same(t, slices.Collect(m.Keys()), keys)
+			}
+
+			// the tree stays valid when modified after the bulk load.
+			m.Insert(1, 1)
+			m.Erase(0)
+			verify(t, m)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("FromSorted with unsorted keys must panic")
+		}
+	}()
+	FromSorted(2, less, func(yield func(int, int) bool) {
+		for _, k := range []int{1, 2, 4, 3, 5, 6} {
+			if !yield(k, k) {
+				return
+			}
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	m := New[int, int](2, less)
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, i)
+	}
+	clone := m.Clone()
+	for i := 0; i < 1000; i += 2 {
+		m.Erase(i)
+		clone.InsertOrAssign(i+1, -i)
+	}
+	m.Insert(5000, 5000)
+	third := clone.Clone()
+	third.Clear()
+	verify(t, m)
+	verify(t, clone)
+	same(t, m.Size(), 501)
+	same(t, clone.Size(), 1000)
+	same(t, third.Size(), 0)
+
+	for i := 0; i < 1000; i++ {
+		value, ok := m.Get(i)
+		same(t, ok, i%2 == 1)
+		if ok {
+			same(t, value, i)
+		}
+		value, _ = clone.Get(i)
+		if i%2 == 1 {
+			same(t, value, -(i - 1))
+		} else {
+			same(t, value, i)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet[string](2, func(a string, b string) bool {
+		return a < b
+	})
+	for _, k := range []string{"d", "b", "a", "c", "e"} {
+		same(t, s.Insert(k), true)
+	}
+	same(t, s.Insert("a"), false)
+	same(t, s.Size(), 5)
+	same(t, s.Contains("c"), true)
+	front, _ := s.Front()
+	back, _ := s.Back()
+	same(t, front+back, "ae")
+	same(t, slices.Collect(s.All()), []string{"a", "b", "c", "d", "e"})
+	same(t, slices.Collect(s.Backward()), []string{"e", "d", "c", "b", "a"})
+	same(t, slices.Collect(s.Range("b", "d")), []string{"b", "c"})
+
+	clone := s.Clone()
+	same(t, s.Erase("c"), true)
+	same(t, s.Erase("c"), false)
+	same(t, clone.Contains("c"), true)
+	s.Clear()
+	same(t, s.Empty(), true)
+	same(t, clone.Size(), 5)
+
+	loaded := SetFromSorted(3, less, slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	verify(t, &loaded.m)
+	same(t, slices.Collect(loaded.All()), []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+}
+
+// sortedVector is the baseline of the benchmarks: a vector kept sorted with binary search.
+type sortedVector struct {
+	vec vector.Vector[int64]
+}
+
+func (s *sortedVector) Insert(key int64) {
+	pos := sort.Search(s.vec.Size(), func(i int) bool { return s.vec[i] >= key })
+	if pos == s.vec.Size() || s.vec[pos] != key {
+		s.vec.Insert(pos, key)
+	}
+}
+
+func (s *sortedVector) Contains(key int64) bool {
+	_, found := slices.BinarySearch(s.vec, key)
+	return found
+}
+
+func int64Less(a int64, b int64) bool {
+	return a < b
+}
+
+func BenchmarkInsert(b *testing.B) {
+	const N = 100000
+	keys := make([]int64, N)
+	for i := range keys {
+		keys[i] = rand.Int63()
+	}
+
+	for _, degree := range []int{2, 8, DefaultDegree, 128} {
+		b.Run(fmt.Sprint("BTree/degree=", degree), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := NewSet(degree, int64Less)
+				for _, k := range keys {
+					s.Insert(k)
+				}
+			}
+		})
+	}
+	b.Run("SortedVector", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var s sortedVector
+			for _, k := range keys {
+				s.Insert(k)
+			}
+		}
+	})
+}
+
+func BenchmarkContains(b *testing.B) {
+	const N = 1000000
+	keys := make([]int64, N)
+	for i := range keys {
+		keys[i] = rand.Int63()
+	}
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	for _, degree := range []int{2, 8, DefaultDegree, 128} {
+		b.Run(fmt.Sprint("BTree/degree=", degree), func(b *testing.B) {
+			s := SetFromSorted(degree, int64Less, slices.Values(sorted))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Contains(keys[i%N])
+			}
+		})
+	}
+	b.Run("SortedVector", func(b *testing.B) {
+		s := sortedVector{vec: *vector.NewWithData(sorted...)}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s.Contains(keys[i%N])
+		}
+	})
+}
+
+func BenchmarkRange(b *testing.B) {
+	const N = 1000000
+	sorted := make([]int64, N)
+	for i := range sorted {
+		sorted[i] = int64(i)
+	}
+
+	b.Run(fmt.Sprint("BTree/degree=", DefaultDegree), func(b *testing.B) {
+		s := SetFromSorted(DefaultDegree, int64Less, slices.Values(sorted))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			lo := int64(i % (N - 100))
+			for range s.Range(lo, lo+100) {
+			}
+		}
+	})
+	b.Run("SortedVector", func(b *testing.B) {
+		s := sortedVector{vec: *vector.NewWithData(sorted...)}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			lo := int64(i % (N - 100))
+			first, _ := slices.BinarySearch(s.vec, lo)
+			last, _ := slices.BinarySearch(s.vec, lo+100)
+			for range s.vec[first:last] {
+			}
+		}
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package btree
+
+// item is a key-value pair stored in a node.
+type item[K any, V any] struct {
+	key   K
+	value V
+}
+
+// copyOnWrite identifies the tree which owns a node.
+// A node may only be modified by the tree whose copyOnWrite it points to,
+// any other tree sharing the node has to copy it first.
+type copyOnWrite struct {
+	// not empty, so that every allocation has a distinct address.
+	_ byte
+}
+
+// node is a node of a B-tree.
+// A leaf has no children, an internal node has len(items)+1 children.
+type node[K any, V any] struct {
+	items    []item[K, V]
+	children []*node[K, V]
+	cow      *copyOnWrite
+}
+
+// insertAt inserts value into s at index i.
+func insertAt[T any](s []T, i int, value T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = value
+	return s
+}
+
+// removeAt removes the element at index i of s and returns it.
+func removeAt[T any](s []T, i int) ([]T, T) {
+	var zero T
+	value := s[i]
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = zero // avoid memory leaks
+	return s[:len(s)-1], value
+}
+
+// pop removes the last element of s and returns it.
+func pop[T any](s []T) ([]T, T) {
+	return removeAt(s, len(s)-1)
+}
+
+// truncate shortens s to n elements.
+func truncate[T any](s []T, n int) []T {
+	clear(s[n:]) // avoid memory leaks
+	return s[:n]
+}
+
+// mutableFor returns n if it is owned by cow, otherwise a copy of n owned by cow.
+func (n *node[K, V]) mutableFor(cow *copyOnWrite) *node[K, V] {
+	if n.cow == cow {
+		return n
+	}
+	out := &node[K, V]{cow: cow}
+	out.items = append(make([]item[K, V], 0, cap(n.items)), n.items...)
+	if len(n.children) > 0 {
+		out.children = append(make([]*node[K, V], 0, cap(n.children)), n.children...)
+	}
+	return out
+}
+
+// mutableChild makes the i-th child of n mutable and returns it.
+func (n *node[K, V]) mutableChild(i int) *node[K, V] {
+	child := n.children[i].mutableFor(n.cow)
+	n.children[i] = child
+	return child
+}
+
+// find returns the index of the first item whose key is not less than key,
+// and whether the key of that item is equivalent to key.
+func (n *node[K, V]) find(key K, less func(left K, right K) bool) (int, bool) {
+	low, high := 0, len(n.items)
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if less(n.items[mid].key, key) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, low < len(n.items) && !less(key, n.items[low].key)
+}
+
+// split splits n at index i.
+// The item at i is returned, the items and children after it are moved into a new node.
+func (n *node[K, V]) split(i int) (item[K, V], *node[K, V]) {
+	middle := n.items[i]
+	next := &node[K, V]{cow: n.cow}
+	next.items = append(make([]item[K, V], 0, cap(n.items)), n.items[i+1:]...)
+	n.items = truncate(n.items, i)
+	if len(n.children) > 0 {
+		next.children = append(make([]*node[K, V], 0, cap(n.children)), n.children[i+1:]...)
+		n.children = truncate(n.children, i+1)
+	}
+	return middle, next
+}
+
+// maybeSplitChild splits the i-th child of n if it is full, and returns whether it has been split.
+func (n *node[K, V]) maybeSplitChild(i int, maxItems int) bool {
+	if len(n.children[i].items) < maxItems {
+		return false
+	}
+	first := n.mutableChild(i)
+	middle, second := first.split(maxItems / 2)
+	n.items = insertAt(n.items, i, middle)
+	n.children = insertAt(n.children, i+1, second)
+	return true
+}
+
+// insert inserts it into the subtree n, which must not be full.
+// If an item with an equivalent key exists, its value is replaced when replace is true,
+// and insert returns false.
+func (n *node[K, V]) insert(it item[K, V], maxItems int, replace bool, less func(left K, right K) bool) bool {
+	i, found := n.find(it.key, less)
+	if found {
+		if replace {
+			n.items[i].value = it.value
+		}
+		return false
+	}
+	if len(n.children) == 0 {
+		n.items = insertAt(n.items, i, it)
+		return true
+	}
+	if n.maybeSplitChild(i, maxItems) {
+		middle := n.items[i].key
+		if less(middle, it.key) {
+			i++
+		} else if !less(it.key, middle) {
+			if replace {
+				n.items[i].value = it.value
+			}
+			return false
+		}
+	}
+	return n.mutableChild(i).insert(it, maxItems, replace, less)
+}
+
+// removeKind tells which item remove removes.
+type removeKind int
+
+const (
+	removeKey removeKind = iota
+	removeMin
+	removeMax
+)
+
+// remove removes an item from the subtree n and returns it.
+// Every node on the path keeps at least minItems items after the removal.
+func (n *node[K, V]) remove(key K, minItems int, kind removeKind, less func(left K, right K) bool) (removed item[K, V], ok bool) {
+	var i int
+	var found bool
+	switch kind {
+	case removeMax:
+		if len(n.children) == 0 {
+			n.items, removed = pop(n.items)
+			return removed, true
+		}
+		i = len(n.items)
+	case removeMin:
+		if len(n.children) == 0 {
+			n.items, removed = removeAt(n.items, 0)
+			return removed, true
+		}
+		i = 0
+	case removeKey:
+		i, found = n.find(key, less)
+		if len(n.children) == 0 {
+			if found {
+				n.items, removed = removeAt(n.items, i)
+				return removed, true
+			}
+			return
+		}
+	}
+
+	// the child is too small to lose an item.
+	if len(n.children[i].items) <= minItems {
+		return n.growChildAndRemove(i, key, minItems, kind, less)
+	}
+	child := n.mutableChild(i)
+	if found {
+		// replace the item with its predecessor.
+		removed = n.items[i]
+		n.items[i], _ = child.remove(key, minItems, removeMax, less)
+		return removed, true
+	}
+	return child.remove(key, minItems, kind, less)
+}
+
+// growChildAndRemove gives the i-th child of n an extra item, by stealing from a sibling or merging with it,
+// and then retries remove.
+func (n *node[K, V]) growChildAndRemove(i int, key K, minItems int, kind removeKind, less func(left K, right K) bool) (item[K, V], bool) {
+	if i > 0 && len(n.children[i-1].items) > minItems {
+		// steal from the left sibling.
+		child := n.mutableChild(i)
+		from := n.mutableChild(i - 1)
+		var stolen item[K, V]
+		from.items, stolen = pop(from.items)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = stolen
+		if len(from.children) > 0 {
+			var grandchild *node[K, V]
+			from.children, grandchild = pop(from.children)
+			child.children = insertAt(child.children, 0, grandchild)
+		}
+	} else if i < len(n.items) && len(n.children[i+1].items) > minItems {
+		// steal from the right sibling.
+		child := n.mutableChild(i)
+		from := n.mutableChild(i + 1)
+		var stolen item[K, V]
+		from.items, stolen = removeAt(from.items, 0)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = stolen
+		if len(from.children) > 0 {
+			var grandchild *node[K, V]
+			from.children, grandchild = removeAt(from.children, 0)
+			child.children = append(child.children, grandchild)
+		}
+	} else {
+		// merge with the right sibling, or the left one if the child is the last.
+		if i >= len(n.items) {
+			i--
+		}
+		child := n.mutableChild(i)
+		var middle item[K, V]
+		var right *node[K, V]
+		n.items, middle = removeAt(n.items, i)
+		n.children, right = removeAt(n.children, i+1)
+		child.items = append(child.items, middle)
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+	}
+	return n.remove(key, minItems, kind, less)
+}
+
+// ascend yields the items of the subtree n in ascending order whose keys are in [lo, hi).
+// A nil lo or hi means no bound. ascend returns false if yield asked to stop.
+func (n *node[K, V]) ascend(lo *K, hi *K, less func(left K, right K) bool, yield func(K, V) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = n.find(*lo, less)
+	}
+	for ; i < len(n.items); i++ {
+		if len(n.children) > 0 {
+			if !n.children[i].ascend(lo, hi, less, yield) {
+				return false
+			}
+			// the following children are all greater than lo.
+			lo = nil
+		}
+		if hi != nil && !less(n.items[i].key, *hi) {
+			return false
+		}
+		if !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+	if len(n.children) > 0 {
+		return n.children[len(n.items)].ascend(lo, hi, less, yield)
+	}
+	return true
+}
+
+// descend yields the items of the subtree n in descending order.
+// descend returns false if yield asked to stop.
+func (n *node[K, V]) descend(yield func(K, V) bool) bool {
+	for i := len(n.items) - 1; i >= 0; i-- {
+		if len(n.children) > 0 && !n.children[i+1].descend(yield) {
+			return false
+		}
+		if !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+	if len(n.children) > 0 {
+		return n.children[0].descend(yield)
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package btree
+
+import "iter"
+
+// Set is an ordered set with unique keys backed by a B-tree.
+// A Set is not safe for concurrent use, but clones may be used by different goroutines.
+type Set[K any] struct {
+	m Map[K, struct{}]
+}
+
+// NewSet creates an empty Set[K] ordered by comparator, whose nodes hold at most 2*degree-1 keys.
+// NewSet panics if degree < 2.
+// Comparator must not be nil.
+func NewSet[K any](degree int, comparator func(left K, right K) bool) *Set[K] {
+	return &Set[K]{
+		m: *New[K, struct{}](degree, comparator),
+	}
+}
+
+// SetFromSorted creates a Set[K] which contains the keys of seq in O(n) time.
+// The keys must be yielded in strictly ascending order according to comparator, otherwise SetFromSorted panics.
+// SetFromSorted panics if degree < 2.
+// Comparator must not be nil.
+func SetFromSorted[K any](degree int, comparator func(left K, right K) bool, seq iter.Seq[K]) *Set[K] {
+	pairs := func(yield func(K, struct{}) bool) {
+		for k := range seq {
+			if !yield(k, struct{}{}) {
+				return
+			}
+		}
+	}
+	return &Set[K]{
+		m: *FromSorted(degree, comparator, pairs),
+	}
+}
+
+// Size returns the number of keys in the set.
+func (s *Set[K]) Size() int {
+	return s.m.Size()
+}
+
+// Empty returns true if the set is empty.
+func (s *Set[K]) Empty() bool {
+	return s.m.Empty()
+}
+
+// Clear removes all keys of the set.
+func (s *Set[K]) Clear() {
+	s.m.Clear()
+}
+
+// Clone returns a copy of the set in O(1) time.
+// The set and its clone share their nodes until one of them modifies a node, which is then copied.
+func (s *Set[K]) Clone() *Set[K] {
+	return &Set[K]{
+		m: *s.m.Clone(),
+	}
+}
+
+// Contains returns true if the set contains key.
+func (s *Set[K]) Contains(key K) bool {
+	return s.m.Contains(key)
+}
+
+// Insert inserts key into the set and returns true.
+// If the set already contains key, the set is not modified and Insert returns false.
+func (s *Set[K]) Insert(key K) bool {
+	return s.m.Insert(key, struct{}{})
+}
+
+// Erase removes key from the set and returns true.
+// If the set does not contain key, the set is not modified and Erase returns false.
+func (s *Set[K]) Erase(key K) bool {
+	return s.m.Erase(key)
+}
+
+// Front returns the smallest key.
+// If the set is empty, Front will return the default value of K and false.
+func (s *Set[K]) Front() (key K, ok bool) {
+	key, _, ok = s.m.Front()
+	return
+}
+
+// Back returns the largest key.
+// If the set is empty, Back will return the default value of K and false.
+func (s *Set[K]) Back() (key K, ok bool) {
+	key, _, ok = s.m.Back()
+	return
+}
+
+// All returns an iterator over the keys of the set in ascending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) All() iter.Seq[K] {
+	return s.m.Keys()
+}
+
+// Backward returns an iterator over the keys of the set in descending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) Backward() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s.m.Backward() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys of the set in [lo, hi), in ascending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) Range(lo K, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s.m.Range(lo, hi) {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package cache defines the interface shared by the cache policies of this module,
+// and a helper to measure their hit ratios.
+//
+// The policies are implemented in their own packages:
+//
+//   - lru: least recently used, the cheapest, but a scan of cold keys flushes it.
+//   - lfu: least frequently used, keeps the keys which are used often, but is slow to forget them.
+//   - arc: adaptive replacement cache, balances recency and frequency and resists scans.
+package cache
+
+import "iter"
+
+// Cache is a cache of values indexed by keys with a bounded capacity.
+type Cache[K comparable, V any] interface {
+	// Get returns the value of key and true, and records the access.
+	// If the cache does not contain key, Get returns the default value of V and false.
+	Get(key K) (V, bool)
+	// Peek returns the value of key and true, without recording the access.
+	// If the cache does not contain key, Peek returns the default value of V and false.
+	Peek(key K) (V, bool)
+	// Contains returns true if the cache contains key, without recording the access.
+	Contains(key K) bool
+	// Put puts key with value, evicting entries if the cache is full.
+	Put(key K, value V)
+	// Remove removes key from the cache and returns true, or returns false if the cache does not contain key.
+	Remove(key K) bool
+	// Size returns the number of entries in the cache.
+	Size() int
+	// Capacity returns the maximum size of the cache.
+	Capacity() int
+	// Clear removes all entries of the cache.
+	Clear()
+}
+
+// Stats counts the hits and misses of a cache.
+type Stats struct {
+	Hits   int
+	Misses int
+}
+
+// HitRatio returns the ratio of hits among all accesses, or 0 if there is no access.
+func (stats Stats) HitRatio() float64 {
+	if total := stats.Hits + stats.Misses; total > 0 {
+		return float64(stats.Hits) / float64(total)
+	}
+	return 0
+}
+
+// Replay replays a trace of keys on c and returns the hits and misses.
+// Every key is looked up with Get, and put with the value returned by load on a miss.
+// Load must not be nil.
+func Replay[K comparable, V any](c Cache[K, V], trace iter.Seq[K], load func(key K) V) Stats {
+	var stats Stats
+	for key := range trace {
+		if _, ok := c.Get(key); ok {
+			stats.Hits++
+		} else {
+			stats.Misses++
+			c.Put(key, load(key))
+		}
+	}
+	return stats
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package cache_test
+
+import (
+	"bufio"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/GitSteve1025/containers/arc"
+	"github.com/GitSteve1025/containers/cache"
+	"github.com/GitSteve1025/containers/lfu"
+	"github.com/GitSteve1025/containers/lru"
+)
+
+// the trace files hold one key per line, they are replayed by BenchmarkReplay besides the generated traces.
+var traces = flag.String("trace", "", "glob of the trace files replayed by BenchmarkReplay")
+
+var (
+	_ cache.Cache[int, int] = (*lru.Cache[int, int])(nil)
+	_ cache.Cache[int, int] = (*lfu.Cache[int, int])(nil)
+	_ cache.Cache[int, int] = (*arc.Cache[int, int])(nil)
+)
+
+var policies = []struct {
+	name string
+	new  func(capacity int) cache.Cache[string, string]
+}{
+	{"LRU", func(capacity int) cache.Cache[string, string] { return lru.New[string, string](capacity) }},
+	{"LFU", func(capacity int) cache.Cache[string, string] { return lfu.New[string, string](capacity) }},
+	{"ARC", func(capacity int) cache.Cache[string, string] { return arc.New[string, string](capacity) }},
+}
+
+func identity(key string) string {
+	return key
+}
+
+// scanTrace returns n accesses to a skewed hot set of 800 keys,
+// interrupted every 5000 accesses by a scan of 3000 cold keys which are never accessed again.
+func scanTrace(n int) []string {
+	r := rand.New(rand.NewPCG(1, 2))
+	hot := rand.NewZipf(r, 1.2, 1, 799)
+	keys := make([]string, 0, n)
+	cold := 0
+	for len(keys) < n {
+		if len(keys)%5000 == 4999 {
+			for i := 0; i < 3000 && len(keys) < n; i++ {
+				keys = append(keys, "cold"+strconv.Itoa(cold))
+				cold++
+			}
+			continue
+		}
+		keys = append(keys, strconv.FormatUint(hot.Uint64(), 10))
+	}
+	return keys
+}
+
+// zipfTrace returns n accesses skewed over 100000 keys.
+func zipfTrace(n int) []string {
+	zipf := rand.NewZipf(rand.New(rand.NewPCG(3, 4)), 1.1, 1, 99999)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strconv.FormatUint(zipf.Uint64(), 10)
+	}
+	return keys
+}
+
+// readTrace reads the keys of a trace file.
+func readTrace(tb testing.TB, name string) []string {
+	tb.Helper()
+	file, err := os.Open(name)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+	var keys []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			keys = append(keys, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		tb.Fatal(err)
+	}
+	return keys
+}
+
+func TestReplay(t *testing.T) {
+	trace := []string{"a", "b", "a", "c", "a", "b"}
+	for _, policy := range policies {
+		c := policy.new(2)
+		stats := cache.Replay(c, slices.Values(trace), identity)
+		if stats.Hits+stats.Misses != len(trace) || stats.Hits < 2 {
+			t.Error(policy.name, "replay is invalid", stats)
+		}
+		if c.Size() != 2 || c.Capacity() != 2 {
+			t.Error(policy.name, "size is invalid")
+		}
+		c.Clear()
+		if c.Size() != 0 || c.Contains("a") {
+			t.Error(policy.name, "clear is invalid")
+		}
+	}
+	if (cache.Stats{}).HitRatio() != 0 || (cache.Stats{Hits: 1, Misses: 3}).HitRatio() != 0.25 {
+		t.Error("HitRatio is invalid")
+	}
+}
+
+func TestScanResistance(t *testing.T) {
+	keys := scanTrace(60000)
+	ratios := make(map[string]float64)
+	for _, policy := range policies {
+		ratios[policy.name] = cache.Replay(policy.new(1000), slices.Values(keys), identity).HitRatio()
+	}
+	if ratios["ARC"] <= ratios["LRU"] || ratios["LFU"] <= ratios["LRU"] {
+		t.Error("the scans thrash ARC or LFU more than LRU", ratios)
+	}
+}
+
+// BenchmarkReplay replays the generated traces and the trace files on every policy and reports their hit ratios.
+//
+// The -trace flag must follow -args, as go test has a -trace flag of its own:
+//
+//	go test ./cache -run XXX -bench Replay -args -trace 'path/to/*.trace'
+func BenchmarkReplay(b *testing.B) {
+	generated := map[string][]string{
+		"scan": scanTrace(60000),
+		"zipf": zipfTrace(60000),
+	}
+	names := []string{"scan", "zipf"}
+	if *traces != "" {
+		files, err := filepath.Glob(*traces)
+		if err != nil {
+			b.Fatal(err)
+		}
+		names = append(names, files...)
+	}
+	for _, name := range names {
+		keys, ok := generated[name]
+		if !ok {
+			keys = readTrace(b, name)
+		}
+		for _, capacity := range []int{100, 1000, 10000} {
+			for _, policy := range policies {
+				b.Run(filepath.Base(name)+"/"+policy.name+"/"+strconv.Itoa(capacity), func(b *testing.B) {
+					var stats cache.Stats
+					for i := 0; i < b.N; i++ {
+						stats = cache.Replay(policy.new(capacity), slices.Values(keys), identity)
+					}
+					b.ReportMetric(stats.HitRatio()*100, "hit%")
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(keys)), "ns/access")
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package deque implements a double-ended queue backed by a growable circular buffer.
+// It supports O(1) push and pop at both ends and O(1) random access.
+package deque
+
+import "iter"
+
+// minCapacity is the capacity allocated when an empty deque first needs memory.
+const minCapacity = 8
+
+type Deque[T any] struct {
+	// circular buffer, len(buffer) is always zero or a power of two.
+	buffer []T
+	// index of the first element in buffer.
+	head int
+	// number of elements in the deque.
+	size int
+}
+
+// New creates a new empty Deque[T].
+func New[T any]() *Deque[T] {
+	return new(Deque[T])
+}
+
+// NewWithData creates a Deque[T] with data.
+// Data will be placed in order.
+func NewWithData[T any](data ...T) *Deque[T] {
+	deque := New[T]()
+	deque.reserve(len(data))
+	for _, x := range data {
+		deque.PushBack(x)
+	}
+	return deque
+}
+
+// Size returns the number of elements in the deque.
+func (deque *Deque[T]) Size() int {
+	return deque.size
+}
+
+// Capacity returns the total number of elements that the deque can hold before needing to allocate more memory.
+func (deque *Deque[T]) Capacity() int {
+	return len(deque.buffer)
+}
+
+// Empty returns true if the deque is empty.
+func (deque *Deque[T]) Empty() bool {
+	return deque.size == 0
+}
+
+// index returns the position in buffer of the i-th element of the deque.
+func (deque *Deque[T]) index(i int) int {
+	return (deque.head + i) & (len(deque.buffer) - 1)
+}
+
+// reserve makes sure the buffer can hold at least n elements.
+// The elements are moved to the beginning of the new buffer.
+func (deque *Deque[T]) reserve(n int) {
+	if n <= len(deque.buffer) {
+		return
+	}
+	capacity := max(minCapacity, len(deque.buffer)*2)
+	for capacity < n {
+		capacity *= 2
+	}
+	temp := make([]T, capacity)
+	if deque.size > 0 {
+		// the elements may wrap around the end of buffer.
+		k := copy(temp[:deque.size], deque.buffer[deque.head:])
+		copy(temp[k:deque.size], deque.buffer)
+	}
+	deque.buffer = temp
+	deque.head = 0
+}
+
+// Front returns the reference of data at the first element of the deque.
+// Front will return nil if it is empty.
+func (deque *Deque[T]) Front() *T {
+	if deque.size > 0 {
+		return &deque.buffer[deque.head]
+	}
+	return nil
+}
+
+// Back returns the reference of data at the last element of the deque.
+// Back will return nil if it is empty.
+func (deque *Deque[T]) Back() *T {
+	if deque.size > 0 {
+		return &deque.buffer[deque.index(deque.size-1)]
+	}
+	return nil
+}
+
+// At returns a reference to the data at position pos.
+// If pos is out of range, At will return nil.
+func (deque *Deque[T]) At(pos int) *T {
+	if 0 <= pos && pos < deque.size {
+		return &deque.buffer[deque.index(pos)]
+	}
+	return nil
+}
+
+// PushBack adds data to the end of the deque with an amortized time complexity of O(1).
+func (deque *Deque[T]) PushBack(val T) {
+	deque.reserve(deque.size + 1)
+	deque.buffer[deque.index(deque.size)] = val
+	deque.size++
+}
+
+// PushFront adds data to the begin of the deque with an amortized time complexity of O(1).
+func (deque *Deque[T]) PushFront(val T) {
+	deque.reserve(deque.size + 1)
+	deque.head = deque.index(len(deque.buffer) - 1)
+	deque.buffer[deque.head] = val
+	deque.size++
+}
+
+// PopBack removes last element and returns the value of the element.
+// When deque is empty, deque will not be modified.
+// PopBack returns the default value of T when deque is empty.
+func (deque *Deque[T]) PopBack() (value T) {
+	if deque.size > 0 {
+		var zero T
+		i := deque.index(deque.size - 1)
+		temp := deque.buffer[i]
+		deque.buffer[i] = zero // avoid memory leaks
+		deque.size--
+		return temp
+	}
+	return
+}
+
+// PopFront removes the first element and returns the value of the element.
+// When deque is empty, deque will not be modified.
+// PopFront returns the default value of T when deque is empty.
+func (deque *Deque[T]) PopFront() (value T) {
+	if deque.size > 0 {
+		var zero T
+		temp := deque.buffer[deque.head]
+		deque.buffer[deque.head] = zero // avoid memory leaks
+		deque.head = deque.index(1)
+		deque.size--
+		return temp
+	}
+	return
+}
+
+// Insert inserts given value into deque before specified position.
+// Only the elements on the shorter side of pos are moved, so the complexity is O(min(pos, Size()-pos)).
+// If pos < 0 or pos > Size(), deque will not be modified.
+func (deque *Deque[T]) Insert(pos int, val T) {
+	if pos < 0 || pos > deque.size {
+		return
+	}
+	var zero T
+	if pos < deque.size/2 {
+		deque.PushFront(zero)
+		for i := 0; i < pos; i++ {
+			deque.buffer[deque.index(i)] = deque.buffer[deque.index(i+1)]
+		}
+	} else {
+		deque.PushBack(zero)
+		for i := deque.size - 1; i > pos; i-- {
+			deque.buffer[deque.index(i)] = deque.buffer[deque.index(i-1)]
+		}
+	}
+	deque.buffer[deque.index(pos)] = val
+}
+
+// Erase removes element at given position and returns the value of the element.
+// Only the elements on the shorter side of pos are moved, so the complexity is O(min(pos, Size()-pos)).
+// When pos is out of range, deque will not be modified and erase will return the default value of T.
+func (deque *Deque[T]) Erase(pos int) (value T) {
+	if pos < 0 || pos >= deque.size {
+		return
+	}
+	temp := deque.buffer[deque.index(pos)]
+	if pos < deque.size/2 {
+		for i := pos; i > 0; i-- {
+			deque.buffer[deque.index(i)] = deque.buffer[deque.index(i-1)]
+		}
+		deque.PopFront()
+	} else {
+		for i := pos; i < deque.size-1; i++ {
+			deque.buffer[deque.index(i)] = deque.buffer[deque.index(i+1)]
+		}
+		deque.PopBack()
+	}
+	return temp
+}
+
+// Resize resizes the deque to the specified number of elements.
+// New elements are set to the default value of T.
+// If n < 0, deque will not be modified.
+func (deque *Deque[T]) Resize(n int) {
+	if n < 0 {
+		return
+	}
+	deque.reserve(n)
+	// removed slots are cleared to avoid memory leaks, unused slots are always the default value of T.
+	var zero T
+	for i := n; i < deque.size; i++ {
+		deque.buffer[deque.index(i)] = zero
+	}
+	deque.size = n
+}
+
+// ShrinkToFit reduces Capacity() to Size() rounded up to a power of two.
+// This function will allocate new space.
+func (deque *Deque[T]) ShrinkToFit() {
+	temp := New[T]()
+	temp.reserve(deque.size)
+	for i := 0; i < deque.size; i++ {
+		temp.buffer[i] = deque.buffer[deque.index(i)]
+	}
+	temp.size = deque.size
+	*deque = *temp
+}
+
+// Clear clears Deque[T].
+// The capacity of the deque is kept so that its memory can be reused.
+func (deque *Deque[T]) Clear() {
+	clear(deque.buffer) // avoid memory leaks
+	deque.head = 0
+	deque.size = 0
+}
+
+// All returns an iterator over index-value pairs of the deque in order.
+func (deque *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < deque.size; i++ {
+			if !yield(i, deque.buffer[deque.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the deque in order.
+func (deque *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < deque.size; i++ {
+			if !yield(deque.buffer[deque.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs of the deque, traversing it backward with descending indices.
+func (deque *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := deque.size - 1; i >= 0; i-- {
+			if !yield(i, deque.buffer[deque.index(i)]) {
+				return
+			}
+		}
+	}
+}
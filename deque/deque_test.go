@@ -0,0 +1,182 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package deque
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+// values returns the elements of the deque in order.
+func values[T any](deque *Deque[T]) []T {
+	var result []T
+	for i := 0; i < deque.Size(); i++ {
+		result = append(result, *deque.At(i))
+	}
+	return result
+}
+
+func TestPushPop(t *testing.T) {
+	deque := New[int]()
+	same(t, deque.Front() == nil, true)
+	same(t, deque.Back() == nil, true)
+	same(t, deque.PopBack(), 0)  // default value
+	same(t, deque.PopFront(), 0) // default value
+
+	for i := 0; i < 10; i++ {
+		deque.PushBack(i)
+		deque.PushFront(-i)
+		same(t, *deque.Front(), -i)
+		same(t, *deque.Back(), i)
+	}
+	same(t, deque.Size(), 20)
+
+	for i := 9; i >= 0; i-- {
+		same(t, deque.PopFront(), -i)
+		same(t, deque.PopBack(), i)
+	}
+	same(t, deque.Empty(), true)
+}
+
+func TestAt(t *testing.T) {
+	deque := NewWithData("a", "b", "c")
+	deque.PushFront("z")
+	same(t, values(deque), []string{"z", "a", "b", "c"})
+	*deque.At(1) = "y"
+	same(t, *deque.At(1), "y")
+	same(t, deque.At(-1) == nil, true)
+	same(t, deque.At(4) == nil, true)
+}
+
+func TestInsertErase(t *testing.T) {
+	deque := NewWithData(1, 2, 3, 4, 5)
+	deque.Insert(0, 0)
+	deque.Insert(6, 6)
+	deque.Insert(3, 9)
+	same(t, values(deque), []int{0, 1, 2, 9, 3, 4, 5, 6})
+
+	deque.Insert(-1, 100) // nothing to do
+	deque.Insert(9, 100)  // nothing to do
+	same(t, deque.Size(), 8)
+
+	same(t, deque.Erase(3), 9)
+	same(t, deque.Erase(0), 0)
+	same(t, deque.Erase(5), 6)
+	same(t, deque.Erase(5), 0) // default value
+	same(t, values(deque), []int{1, 2, 3, 4, 5})
+}
+
+func TestResize(t *testing.T) {
+	deque := NewWithData(1, 2, 3, 4, 5)
+	deque.PopFront()
+	deque.PushBack(6)
+	deque.Resize(10)
+	same(t, values(deque), []int{2, 3, 4, 5, 6, 0, 0, 0, 0, 0})
+	deque.Resize(3)
+	same(t, values(deque), []int{2, 3, 4})
+	deque.Resize(5)
+	same(t, values(deque), []int{2, 3, 4, 0, 0})
+	deque.Resize(-1) // nothing to do
+	same(t, deque.Size(), 5)
+}
+
+func TestShrinkToFitAndClear(t *testing.T) {
+	deque := New[int]()
+	for i := 0; i < 100; i++ {
+		deque.PushFront(i)
+	}
+	for i := 0; i < 90; i++ {
+		deque.PopBack()
+	}
+	deque.ShrinkToFit()
+	same(t, deque.Capacity(), 16)
+	same(t, values(deque), []int{99, 98, 97, 96, 95, 94, 93, 92, 91, 90})
+
+	deque.Clear()
+	same(t, deque.Empty(), true)
+	same(t, deque.Capacity(), 16)
+	deque.Clear()
+}
+
+func TestRandomOperation(t *testing.T) {
+	deque := New[int]()
+	var expect []int
+	for i := 0; i < 100000; i++ {
+		switch rand.Intn(6) {
+		case 0:
+			deque.PushBack(i)
+			expect = append(expect, i)
+		case 1:
+			deque.PushFront(i)
+			expect = slices.Insert(expect, 0, i)
+		case 2:
+			if len(expect) > 0 {
+				same(t, deque.PopBack(), expect[len(expect)-1])
+				expect = expect[:len(expect)-1]
+			}
+		case 3:
+			if len(expect) > 0 {
+				same(t, deque.PopFront(), expect[0])
+				expect = expect[1:]
+			}
+		case 4:
+			pos := rand.Intn(len(expect) + 1)
+			deque.Insert(pos, i)
+			expect = slices.Insert(expect, pos, i)
+		case 5:
+			if len(expect) > 0 {
+				pos := rand.Intn(len(expect))
+				same(t, deque.Erase(pos), expect[pos])
+				expect = slices.Delete(expect, pos, pos+1)
+			}
+		}
+		same(t, deque.Size(), len(expect))
+	}
+	same(t, values(deque), expect)
+}
+
+func TestPushPopEfficiency(t *testing.T) {
+	const N = 10000000
+	deque := New[int]()
+
+	start := time.Now()
+	for i := 0; i < N; i++ {
+		deque.PushFront(i)
+	}
+	t.Log("PushFront", N, "val costs", time.Since(start))
+
+	start = time.Now()
+	for !deque.Empty() {
+		deque.PopBack()
+	}
+	t.Log("PopBack", N, "val costs", time.Since(start))
+}
+
+func TestIterator(t *testing.T) {
+	deque := NewWithData(2, 3, 4)
+	deque.PushFront(1)
+	deque.PushFront(0)
+
+	same(t, slices.Collect(deque.Values()), []int{0, 1, 2, 3, 4})
+	for i, x := range deque.All() {
+		same(t, x, i)
+	}
+	var values []int
+	for i, x := range deque.Backward() {
+		same(t, x, i)
+		values = append(values, x)
+	}
+	same(t, values, []int{4, 3, 2, 1, 0})
+}
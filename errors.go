@@ -0,0 +1,30 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package containers defines the errors shared by the containers of this module.
+//
+// By default, the containers ignore invalid input: an out-of-range position or an element of another
+// container leaves the container unmodified, and the result is nil or a default value.
+// The checked methods, whose names end with E such as AtE or InsertBeforeE, report the same cases
+// with an error wrapping one of the errors below, which can be tested with errors.Is:
+//
+//	if _, err := vec.AtE(i); errors.Is(err, containers.ErrOutOfRange) {
+//		// handle the bad index
+//	}
+//
+// Building with the containers_debug tag, as in go test -tags containers_debug, makes heap, list and vector
+// check their invariants after every mutation and panic with a dump of the container on a violation.
+// Without the tag, the checks compile to nothing.
+package containers
+
+import "errors"
+
+var (
+	// ErrOutOfRange is returned when a position or a range is out of the bounds of a container.
+	ErrOutOfRange = errors.New("containers: out of range")
+	// ErrForeignElement is returned when an element, a handle or a node does not belong to the container.
+	ErrForeignElement = errors.New("containers: foreign element")
+	// ErrEmpty is returned when an element is requested from an empty container.
+	ErrEmpty = errors.New("containers: empty container")
+)
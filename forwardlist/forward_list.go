@@ -0,0 +1,329 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package forwardlist implements a singly linked list, like std::forward_list.
+//
+// An element only holds its value and a pointer to the next element, which makes the list
+// lighter than list.List. In exchange, an element can only be inserted or erased after a given element,
+// and the list does not check that an element belongs to it.
+//
+// The list starts with a before-begin sentinel, so the first element can be handled like the others:
+//
+//	// erase the even values
+//	for e := l.BeforeBegin(); e.Next() != nil; {
+//		if e.Next().Value%2 == 0 {
+//			l.EraseAfter(e)
+//		} else {
+//			e = e.Next()
+//		}
+//	}
+//
+// The zero value of List is an empty list ready to use.
+package forwardlist
+
+import "iter"
+
+type Element[T any] struct {
+	// The value stored in this element.
+	Value T
+	// next pointer of the element.
+	next *Element[T]
+}
+
+// Next returns the next element or nil.
+func (e *Element[T]) Next() *Element[T] {
+	return e.next
+}
+
+// singly-linked list.
+type List[T any] struct {
+	// before-begin sentinel, only root.next is used.
+	root Element[T]
+	// the last element, nil when the list is empty.
+	tail *Element[T]
+	// length of list.
+	size int
+}
+
+// New creates a new empty List[T].
+func New[T any]() *List[T] {
+	return new(List[T])
+}
+
+// NewWithData creates a list which contains data.
+// Data will be placed in order.
+func NewWithData[T any](data ...T) *List[T] {
+	list := New[T]()
+	for _, x := range data {
+		list.PushBack(x)
+	}
+	return list
+}
+
+// FromSeq creates a list which contains the values of seq.
+// Values will be placed in the order in which seq yields them.
+func FromSeq[T any](seq iter.Seq[T]) *List[T] {
+	list := New[T]()
+	for x := range seq {
+		list.PushBack(x)
+	}
+	return list
+}
+
+// Size returns the length of the list.
+func (list *List[T]) Size() int {
+	return list.size
+}
+
+// Empty returns true when list is empty.
+func (list *List[T]) Empty() bool {
+	return list.size == 0
+}
+
+// BeforeBegin returns the sentinel element before the first element.
+// It may be passed to InsertAfter, EraseAfter and the splice methods, but its Value must not be used.
+func (list *List[T]) BeforeBegin() *Element[T] {
+	return &list.root
+}
+
+// Front returns the first element of the list.
+// Return nil when the list is empty.
+func (list *List[T]) Front() *Element[T] {
+	return list.root.next
+}
+
+// Back returns the last element of the list.
+// Return nil when the list is empty.
+func (list *List[T]) Back() *Element[T] {
+	return list.tail
+}
+
+// setBack sets the last element, e is the sentinel when the list becomes empty.
+func (list *List[T]) setBack(e *Element[T]) {
+	if e == &list.root {
+		list.tail = nil
+	} else {
+		list.tail = e
+	}
+}
+
+// link links the chain from first to last after at, and adds size elements.
+func (list *List[T]) link(at *Element[T], first *Element[T], last *Element[T], size int) {
+	last.next = at.next
+	at.next = first
+	if last.next == nil {
+		list.tail = last
+	}
+	list.size += size
+}
+
+// unlink unlinks the chain from prev.next to last, and removes size elements.
+func (list *List[T]) unlink(prev *Element[T], last *Element[T], size int) {
+	prev.next = last.next
+	last.next = nil
+	if list.tail == last {
+		list.setBack(prev)
+	}
+	list.size -= size
+}
+
+// InsertAfter inserts val after at, and return the new element.
+// The at must be an element of list or BeforeBegin().
+func (list *List[T]) InsertAfter(val T, at *Element[T]) *Element[T] {
+	e := &Element[T]{Value: val}
+	list.link(at, e, e, 1)
+	return e
+}
+
+// PushFront adds data to the begin of the list and returns the new element.
+func (list *List[T]) PushFront(val T) *Element[T] {
+	return list.InsertAfter(val, &list.root)
+}
+
+// PushBack adds data to the end of the list and returns the new element.
+func (list *List[T]) PushBack(val T) *Element[T] {
+	if list.tail == nil {
+		return list.InsertAfter(val, &list.root)
+	}
+	return list.InsertAfter(val, list.tail)
+}
+
+// EraseAfter erases the element after at and returns its value.
+// If at is the last element, the list is not modified, it will return default value of T.
+// The at must be an element of list or BeforeBegin().
+func (list *List[T]) EraseAfter(at *Element[T]) (value T) {
+	if e := at.next; e != nil {
+		list.unlink(at, e, 1)
+		return e.Value
+	}
+	return
+}
+
+// PopFront removes the first element and returns the value of the element.
+// It will return default value of T when list is empty.
+func (list *List[T]) PopFront() T {
+	return list.EraseAfter(&list.root)
+}
+
+// Clear clears the list.
+func (list *List[T]) Clear() {
+	for list.size > 0 {
+		list.EraseAfter(&list.root)
+	}
+}
+
+// SpliceAfter moves all elements of other after at, in O(1) time. Other becomes empty.
+// If other is list, the list is not modified.
+// The at must be an element of list or BeforeBegin().
+func (list *List[T]) SpliceAfter(at *Element[T], other *List[T]) {
+	if other == list || other.size == 0 {
+		return
+	}
+	first, last, size := other.root.next, other.tail, other.size
+	other.unlink(&other.root, last, size)
+	list.link(at, first, last, size)
+}
+
+// SpliceElementAfter moves the element after prev in other to the position after at.
+// If prev is the last element of other, or at is the element to move, the lists are not modified.
+// Other may be list.
+// The at must be an element of list or BeforeBegin(), prev must be an element of other or other.BeforeBegin().
+func (list *List[T]) SpliceElementAfter(at *Element[T], other *List[T], prev *Element[T]) {
+	e := prev.next
+	if e == nil || e == at || prev == at {
+		return
+	}
+	other.unlink(prev, e, 1)
+	list.link(at, e, e, 1)
+}
+
+// SpliceRangeAfter moves the elements of other in the open range (first, last) to the position after at,
+// in time linear in the number of moved elements. A nil last means the end of other.
+// Other may be list, in which case at must not be in the range.
+// The at must be an element of list or BeforeBegin(), first must be an element of other or other.BeforeBegin(),
+// and last must follow first in other.
+func (list *List[T]) SpliceRangeAfter(at *Element[T], other *List[T], first *Element[T], last *Element[T]) {
+	if first == at {
+		return
+	}
+	size := 0
+	end := first
+	for end.next != last {
+		end = end.next
+		size++
+	}
+	if size == 0 {
+		return
+	}
+	begin := first.next
+	other.unlink(first, end, size)
+	list.link(at, begin, end, size)
+}
+
+// Reverse reverses the order of the elements.
+func (list *List[T]) Reverse() {
+	var prev *Element[T]
+	list.tail = list.root.next
+	for e := list.root.next; e != nil; {
+		next := e.next
+		e.next = prev
+		prev, e = e, next
+	}
+	list.root.next = prev
+}
+
+// mergeChains merges the sorted chains a and b, which are linked by next and end with nil.
+// It returns the head of the merged chain, elements of a come before the equivalent elements of b.
+// mergeChains, mergeSort and relink mirror those of package list, a fix to one belongs in both.
+func mergeChains[T any](a *Element[T], b *Element[T], less func(left T, right T) bool) *Element[T] {
+	var head Element[T]
+	tail := &head
+	for a != nil && b != nil {
+		if less(b.Value, a.Value) {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	return head.next
+}
+
+// mergeSort sorts the first n elements of the chain starting at head, with n > 0.
+// It returns the head of the sorted chain, which ends with nil, and the rest of the chain.
+func mergeSort[T any](head *Element[T], n int, less func(left T, right T) bool) (*Element[T], *Element[T]) {
+	if n == 1 {
+		rest := head.next
+		head.next = nil
+		return head, rest
+	}
+	a, rest := mergeSort(head, n/2, less)
+	b, rest := mergeSort(rest, n-n/2, less)
+	return mergeChains(a, b, less), rest
+}
+
+// relink makes the chain starting at head, which is linked by next and ends with nil, the elements of list.
+// The tail is rebuilt.
+func (list *List[T]) relink(head *Element[T]) {
+	list.root.next = head
+	list.tail = nil
+	for e := head; e != nil; e = e.next {
+		list.tail = e
+	}
+}
+
+// Sort sorts the list in ascending order according to less with a time complexity of O(n log n).
+// The sort is stable, and the elements are relinked rather than copied.
+// Less must not be nil.
+func (list *List[T]) Sort(less func(left T, right T) bool) {
+	if list.size < 2 {
+		return
+	}
+	head, _ := mergeSort(list.root.next, list.size, less)
+	list.relink(head)
+}
+
+// Merge merges the sorted other into the sorted list in linear time. Other becomes empty.
+// Elements of list come before the equivalent elements of other.
+// If other is list, the list is not modified.
+// Less must not be nil.
+func (list *List[T]) Merge(other *List[T], less func(left T, right T) bool) {
+	if other == list || other.size == 0 {
+		return
+	}
+	list.relink(mergeChains(list.root.next, other.root.next, less))
+	list.size += other.size
+	other.root.next = nil
+	other.tail = nil
+	other.size = 0
+}
+
+// All returns an iterator over index-value pairs of the list from front to back.
+func (list *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := list.root.next; e != nil; e = e.next {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list from front to back.
+func (list *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := list.root.next; e != nil; e = e.next {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package forwardlist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// check checks the values, the size and the last element of the list.
+func check[T comparable](t *testing.T, list *List[T], values ...T) {
+	t.Helper()
+	got := slices.Collect(list.Values())
+	if !slices.Equal(got, values) {
+		t.Fatal("values", got, "are not equal to", values)
+	}
+	if list.Size() != len(values) || list.Empty() != (len(values) == 0) {
+		t.Fatal("size", list.Size(), "is not", len(values))
+	}
+	var last *Element[T]
+	for e := list.Front(); e != nil; e = e.Next() {
+		last = e
+	}
+	if list.Back() != last {
+		t.Fatal("back is not the last element")
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	var list List[int]
+	check(t, &list)
+	if list.Front() != nil || list.Back() != nil {
+		t.Error("front or back is not nil")
+	}
+	list.PushFront(2)
+	list.PushFront(1)
+	list.PushBack(3)
+	check(t, &list, 1, 2, 3)
+	if list.PopFront() != 1 || list.PopFront() != 2 || list.PopFront() != 3 {
+		t.Error("pop front is wrong")
+	}
+	check(t, &list)
+	if list.PopFront() != 0 {
+		t.Error("pop front of an empty list is not the default value")
+	}
+	list.PushBack(4)
+	check(t, &list, 4)
+}
+
+func TestInsertAndEraseAfter(t *testing.T) {
+	list := NewWithData(1, 2, 3, 4, 5, 6)
+	for e := list.BeforeBegin(); e.Next() != nil; {
+		if e.Next().Value%2 == 0 {
+			list.EraseAfter(e)
+		} else {
+			e = e.Next()
+		}
+	}
+	check(t, list, 1, 3, 5)
+
+	e := list.InsertAfter(4, list.Front().Next())
+	list.InsertAfter(6, list.Back())
+	list.InsertAfter(0, list.BeforeBegin())
+	check(t, list, 0, 1, 3, 4, 5, 6)
+	if list.EraseAfter(e) != 5 || list.EraseAfter(e) != 6 {
+		t.Error("erase after is wrong")
+	}
+	check(t, list, 0, 1, 3, 4)
+	if list.EraseAfter(e) != 0 {
+		t.Error("erase after the last element is not the default value")
+	}
+	check(t, list, 0, 1, 3, 4)
+	list.Clear()
+	check(t, list)
+}
+
+func TestSplice(t *testing.T) {
+	pending := NewWithData(1, 2, 3)
+	running := NewWithData(10, 20)
+	running.SpliceAfter(running.Front(), pending)
+	check(t, running, 10, 1, 2, 3, 20)
+	check(t, pending)
+	running.SpliceAfter(running.Back(), running)
+	check(t, running, 10, 1, 2, 3, 20)
+
+	pending.SpliceAfter(pending.BeforeBegin(), NewWithData(7, 8))
+	check(t, pending, 7, 8)
+	pending.SpliceAfter(pending.Back(), NewWithData(9))
+	check(t, pending, 7, 8, 9)
+
+	// move the element after 3, the last one
+	pending.SpliceElementAfter(pending.BeforeBegin(), running, running.Front().Next().Next().Next())
+	check(t, pending, 20, 7, 8, 9)
+	check(t, running, 10, 1, 2, 3)
+	running.SpliceElementAfter(running.Back(), running, running.BeforeBegin())
+	check(t, running, 1, 2, 3, 10)
+	running.SpliceElementAfter(running.Back(), running, running.Back())
+	check(t, running, 1, 2, 3, 10)
+
+	// move (1, 10) into pending, then (8, end) back
+	pending.SpliceRangeAfter(pending.Back(), running, running.Front(), running.Back())
+	check(t, pending, 20, 7, 8, 9, 2, 3)
+	check(t, running, 1, 10)
+	running.SpliceRangeAfter(running.Front(), pending, pending.Front().Next().Next(), nil)
+	check(t, pending, 20, 7, 8)
+	check(t, running, 1, 9, 2, 3, 10)
+	running.SpliceRangeAfter(running.Back(), running, running.BeforeBegin(), running.Front().Next().Next())
+	check(t, running, 2, 3, 10, 1, 9)
+	running.SpliceRangeAfter(running.Back(), pending, pending.Back(), nil)
+	check(t, running, 2, 3, 10, 1, 9)
+	check(t, pending, 20, 7, 8)
+}
+
+func TestReverse(t *testing.T) {
+	list := New[int]()
+	list.Reverse()
+	check(t, list)
+	list.PushBack(1)
+	list.Reverse()
+	check(t, list, 1)
+	list = NewWithData(1, 2, 3, 4)
+	list.Reverse()
+	check(t, list, 4, 3, 2, 1)
+	list.PushBack(0)
+	check(t, list, 4, 3, 2, 1, 0)
+}
+
+func TestSortAndMerge(t *testing.T) {
+	type pair struct {
+		key   int
+		order int
+	}
+	less := func(left pair, right pair) bool {
+		return left.key < right.key
+	}
+	stable := func(left pair, right pair) int {
+		if left.key != right.key {
+			return left.key - right.key
+		}
+		return left.order - right.order
+	}
+
+	for n := 0; n < 200; n++ {
+		values := make([]pair, n)
+		for i := range values {
+			values[i] = pair{rand.Intn(10), i}
+		}
+		list := NewWithData(values...)
+		list.Sort(less)
+		slices.SortFunc(values, stable)
+		check(t, list, values...)
+
+		others := make([]pair, rand.Intn(50))
+		for i := range others {
+			others[i] = pair{rand.Intn(10), n + i}
+		}
+		other := NewWithData(others...)
+		other.Sort(less)
+		list.Merge(other, less)
+		values = append(values, others...)
+		slices.SortFunc(values, stable)
+		check(t, list, values...)
+		check(t, other)
+		list.Merge(list, less)
+		check(t, list, values...)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	list := FromSeq(slices.Values([]int{1, 2, 3}))
+	check(t, list, 1, 2, 3)
+	for i, v := range list.All() {
+		if v != i+1 {
+			t.Error("all is wrong")
+		}
+	}
+	for v := range list.Values() {
+		if v == 2 {
+			break
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package heap
+
+import (
+	"fmt"
+
+	"github.com/GitSteve1025/containers"
+)
+
+// Errors returned by the checked methods, they are the errors of package containers.
+var (
+	ErrOutOfRange     = containers.ErrOutOfRange
+	ErrForeignElement = containers.ErrForeignElement
+	ErrEmpty          = containers.ErrEmpty
+)
+
+// outOfRange returns an error for index i, which is not in [0, size).
+func outOfRange(i int, size int) error {
+	return fmt.Errorf("heap: index %d out of range [0, %d): %w", i, size, ErrOutOfRange)
+}
+
+// TopE is like Top, but returns an error wrapping ErrEmpty if the heap is empty.
+func (heap *Heap[T]) TopE() (value T, err error) {
+	if len(heap.value) > 0 {
+		return heap.value[0], nil
+	}
+	return value, fmt.Errorf("heap: top: %w", ErrEmpty)
+}
+
+// PopE is like Pop, but returns an error wrapping ErrEmpty if the heap is empty.
+func (heap *Heap[T]) PopE() (value T, err error) {
+	if len(heap.value) > 0 {
+		return heap.Pop(), nil
+	}
+	return value, fmt.Errorf("heap: pop: %w", ErrEmpty)
+}
+
+// AtE is like At, but returns an error wrapping ErrOutOfRange if i is out of range.
+func (heap *Heap[T]) AtE(i int) (*T, error) {
+	if 0 <= i && i < len(heap.value) {
+		return &heap.value[i], nil
+	}
+	return nil, outOfRange(i, len(heap.value))
+}
+
+// FixE is like Fix, but returns an error wrapping ErrOutOfRange if i is out of range.
+func (heap *Heap[T]) FixE(i int) error {
+	if 0 <= i && i < len(heap.value) {
+		heap.Fix(i)
+		return nil
+	}
+	return outOfRange(i, len(heap.value))
+}
+
+// RemoveAtE is like RemoveAt, but returns an error wrapping ErrOutOfRange if i is out of range.
+func (heap *Heap[T]) RemoveAtE(i int) (value T, err error) {
+	if 0 <= i && i < len(heap.value) {
+		return heap.RemoveAt(i), nil
+	}
+	return value, outOfRange(i, len(heap.value))
+}
+
+// UpdateE is like Update, but returns an error wrapping ErrForeignElement
+// if the element referred to by handle is not in the heap.
+func (heap *Heap[T]) UpdateE(handle *Handle, value T) error {
+	if !heap.Contains(handle) {
+		return fmt.Errorf("heap: update: %w", ErrForeignElement)
+	}
+	heap.Update(handle, value)
+	return nil
+}
+
+// RemoveE is like Remove, but returns an error wrapping ErrForeignElement
+// if the element referred to by handle is not in the heap.
+func (heap *Heap[T]) RemoveE(handle *Handle) (value T, err error) {
+	if !heap.Contains(handle) {
+		return value, fmt.Errorf("heap: remove: %w", ErrForeignElement)
+	}
+	return heap.Remove(handle), nil
+}
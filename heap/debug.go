@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package heap
+
+import "fmt"
+
+// debug is true when the containers_debug build tag is set.
+// The invariants of the heap are then checked after every mutation, which costs O(n).
+const debug = true
+
+// check panics with a dump of the heap if the heap property does not hold,
+// or if the handles are out of sync with the values.
+func (heap *Heap[T]) check() {
+	for i := 1; i < len(heap.value); i++ {
+		if parent := (i - 1) / 2; heap.comparator(heap.value[i], heap.value[parent]) {
+			heap.fail(fmt.Sprintf("value[%d] is ordered before its parent value[%d]", i, parent))
+		}
+	}
+	if heap.handles == nil {
+		return
+	}
+	if len(heap.handles) != len(heap.value) {
+		heap.fail(fmt.Sprintf("%d handles for %d values", len(heap.handles), len(heap.value)))
+	}
+	for i, handle := range heap.handles {
+		if handle != nil && handle.index != i {
+			heap.fail(fmt.Sprintf("handles[%d] refers to index %d", i, handle.index))
+		}
+	}
+}
+
+// fail panics with reason and a dump of the heap.
+func (heap *Heap[T]) fail(reason string) {
+	const limit = 32
+	values := fmt.Sprint(heap.value[:min(len(heap.value), limit)])
+	if len(heap.value) > limit {
+		values += " ..."
+	}
+	panic(fmt.Sprintf("heap: invariant violated: %s\nsize: %d\nvalues: %s", reason, len(heap.value), values))
+}
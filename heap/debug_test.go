@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package heap
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustPanic runs f and checks that it panics with a message containing want.
+func mustPanic(t *testing.T, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, want) {
+			t.Fatalf("got panic %q, want a panic containing %q", msg, want)
+		}
+	}()
+	f()
+}
+
+func TestDebugCheck(t *testing.T) {
+	heap := New(func(a int, b int) bool { return a < b })
+	heap.PushSeq(func(yield func(int) bool) {
+		for i := 10; i > 0 && yield(i); i-- {
+		}
+	})
+	handle := heap.PushHandle(0)
+	heap.Update(handle, 20)
+
+	heap.value[1] = -1
+	mustPanic(t, "value[1] is ordered before its parent", func() { heap.Push(100) })
+
+	heap = New(func(a int, b int) bool { return a < b })
+	handle = heap.PushHandle(1)
+	heap.PushHandle(2)
+	handle.index = 1
+	mustPanic(t, "refers to index 1", func() { heap.Push(3) })
+}
@@ -6,9 +6,21 @@
 
 package heap
 
+import "iter"
+
 type Heap[T any] struct {
 	value      []T
 	comparator func(left T, right T) bool
+	// handles[i] is the handle of value[i], or nil if value[i] was not pushed by PushHandle.
+	// handles is nil until PushHandle is called for the first time.
+	handles []*Handle
+}
+
+// Handle refers to an element pushed by PushHandle.
+// It stays valid while the element moves inside the heap, until the element is removed.
+type Handle struct {
+	// index of the element in the heap, -1 when the element has been removed.
+	index int
 }
 
 // New creates an empty heap using the provided comparator.
@@ -29,6 +41,17 @@ func NewWithData[T any](comparator func(left T, right T) bool, data ...T) *Heap[
 	return makeHeap(comparator, data...)
 }
 
+// FromSeq creates a heap using the provided comparator and the values of seq, with a time complexity of O(n).
+// Comparator will be used to build a min heap.
+// Comparator must not be nil.
+func FromSeq[T any](comparator func(left T, right T) bool, seq iter.Seq[T]) *Heap[T] {
+	var data []T
+	for x := range seq {
+		data = append(data, x)
+	}
+	return makeHeap(comparator, data...)
+}
+
 // Size returns the size of the heap.
 func (heap *Heap[T]) Size() int {
 	return len(heap.value)
@@ -49,9 +72,24 @@ func rightChild(parent int) int {
 	return parent*2 + 2
 }
 
+// swap swaps the elements at i and j, and keeps their handles in sync.
+func (heap *Heap[T]) swap(i int, j int) {
+	heap.value[i], heap.value[j] = heap.value[j], heap.value[i]
+	if heap.handles != nil {
+		heap.handles[i], heap.handles[j] = heap.handles[j], heap.handles[i]
+		if heap.handles[i] != nil {
+			heap.handles[i].index = i
+		}
+		if heap.handles[j] != nil {
+			heap.handles[j].index = j
+		}
+	}
+}
+
 // heapify is used to adjust a subtree to ensure it satisfies the heap property.
+// heapify returns the index where the element at parent ends up.
 // // The complexity is O(log n)
-func (heap *Heap[T]) heapify(parent int) {
+func (heap *Heap[T]) heapify(parent int) int {
 	for {
 		smallest := parent
 		left := leftChild(parent)
@@ -65,10 +103,10 @@ func (heap *Heap[T]) heapify(parent int) {
 		}
 
 		if smallest != parent {
-			heap.value[parent], heap.value[smallest] = heap.value[smallest], heap.value[parent]
+			heap.swap(parent, smallest)
 			parent = smallest
 		} else {
-			break
+			return parent
 		}
 	}
 }
@@ -83,11 +121,48 @@ func (heap *Heap[T]) upHeap(child int) {
 		if parent == child || heap.comparator(heap.value[parent], heap.value[child]) {
 			break
 		}
-		heap.value[parent], heap.value[child] = heap.value[child], heap.value[parent]
+		heap.swap(parent, child)
 		child = parent
 	}
 }
 
+// downHeap re-establishes the heap property after the element at i has changed.
+// The element is sifted down by heapify, or up by upHeap if it does not need to move down.
+// The complexity is O(log n)
+func (heap *Heap[T]) downHeap(i int) {
+	if heap.heapify(i) == i {
+		heap.upHeap(i)
+	}
+}
+
+// removeAt removes the element at i and returns its value.
+// The handle of the element, if any, becomes invalid.
+// The complexity is O(log n)
+func (heap *Heap[T]) removeAt(i int) T {
+	n := len(heap.value) - 1
+	if i != n {
+		heap.swap(i, n)
+	}
+
+	var zero T
+	temp := heap.value[n]
+	heap.value[n] = zero // avoid memory leaks
+	heap.value = heap.value[:n]
+	if heap.handles != nil {
+		if handle := heap.handles[n]; handle != nil {
+			handle.index = -1
+		}
+		heap.handles[n] = nil // avoid memory leaks
+		heap.handles = heap.handles[:n]
+	}
+
+	if i != n {
+		heap.downHeap(i)
+	}
+	heap.check()
+	return temp
+}
+
 // makeHeap builds a heap in O(n) time.
 func makeHeap[T any](comparator func(left T, right T) bool, data ...T) *Heap[T] {
 	heap := &Heap[T]{
@@ -98,13 +173,80 @@ func makeHeap[T any](comparator func(left T, right T) bool, data ...T) *Heap[T]
 	for i := len(heap.value)/2 - 1; i >= 0; i-- {
 		heap.heapify(i)
 	}
+	heap.check()
 	return heap
 }
 
 // Push inserts value into the heap with a time complexity of O(log n).
 func (heap *Heap[T]) Push(value T) {
 	heap.value = append(heap.value, value)
+	if heap.handles != nil {
+		heap.handles = append(heap.handles, nil)
+	}
 	heap.upHeap(len(heap.value) - 1)
+	heap.check()
+}
+
+// PushSeq inserts the values of seq into the heap.
+// When the values outnumber the heap, the heap is rebuilt in O(n) time;
+// otherwise each value is pushed with a time complexity of O(log n).
+func (heap *Heap[T]) PushSeq(seq iter.Seq[T]) {
+	n := len(heap.value)
+	for x := range seq {
+		heap.value = append(heap.value, x)
+		if heap.handles != nil {
+			heap.handles = append(heap.handles, nil)
+		}
+	}
+
+	if len(heap.value)-n > n {
+		for i := len(heap.value)/2 - 1; i >= 0; i-- {
+			heap.heapify(i)
+		}
+	} else {
+		for i := n; i < len(heap.value); i++ {
+			heap.upHeap(i)
+		}
+	}
+	heap.check()
+}
+
+// PushHandle inserts value into the heap with a time complexity of O(log n),
+// and returns a handle which can be used to Update or Remove the element later.
+func (heap *Heap[T]) PushHandle(value T) *Handle {
+	if heap.handles == nil {
+		heap.handles = make([]*Handle, len(heap.value), cap(heap.value))
+	}
+	handle := &Handle{index: len(heap.value)}
+	heap.value = append(heap.value, value)
+	heap.handles = append(heap.handles, handle)
+	heap.upHeap(handle.index)
+	heap.check()
+	return handle
+}
+
+// Contains returns true if the element referred to by handle is in the heap.
+func (heap *Heap[T]) Contains(handle *Handle) bool {
+	return handle != nil && 0 <= handle.index && handle.index < len(heap.handles) && heap.handles[handle.index] == handle
+}
+
+// Update replaces the value of the element referred to by handle with a time complexity of O(log n).
+// If the element is not in the heap, the heap is not modified.
+func (heap *Heap[T]) Update(handle *Handle, value T) {
+	if heap.Contains(handle) {
+		heap.value[handle.index] = value
+		heap.downHeap(handle.index)
+		heap.check()
+	}
+}
+
+// Remove removes the element referred to by handle and returns its value with a time complexity of O(log n).
+// If the element is not in the heap, the heap is not modified and Remove will return the default value of T.
+func (heap *Heap[T]) Remove(handle *Handle) (value T) {
+	if heap.Contains(handle) {
+		return heap.removeAt(handle.index)
+	}
+	return
 }
 
 // Top returns the top element of the heap with a time complexity of O(1).
@@ -116,16 +258,79 @@ func (heap *Heap[T]) Top() (value T) {
 	return
 }
 
+// At returns a reference to the element at index i of the underlying array, with index 0 being the top.
+// If i is out of range, At will return nil.
+// If the value is modified through the reference, Fix(i) must be called to re-establish the heap order.
+func (heap *Heap[T]) At(i int) *T {
+	if 0 <= i && i < len(heap.value) {
+		return &heap.value[i]
+	}
+	return nil
+}
+
+// Fix re-establishes the heap order after the element at index i has changed its value with a time complexity of O(log n).
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling RemoveAt(i) followed by a Push of the new value.
+// If i is out of range, the heap is not modified.
+func (heap *Heap[T]) Fix(i int) {
+	if 0 <= i && i < len(heap.value) {
+		heap.downHeap(i)
+		heap.check()
+	}
+}
+
+// RemoveAt removes the element at index i and returns its value with a time complexity of O(log n).
+// If i is out of range, the heap is not modified and RemoveAt will return the default value of T.
+func (heap *Heap[T]) RemoveAt(i int) (value T) {
+	if 0 <= i && i < len(heap.value) {
+		return heap.removeAt(i)
+	}
+	return
+}
+
 // Pop removes the top element of the heap with a time complexity of O(log n).
 // If the heap is empty, Pop will return the default value of T.
 func (heap *Heap[T]) Pop() (value T) {
 	if len(heap.value) > 0 {
-		n := len(heap.value) - 1
-		temp := heap.value[0]
-		heap.value[0] = heap.value[n]
-		heap.value = heap.value[:n]
-		heap.heapify(0)
-		return temp
+		return heap.removeAt(0)
 	}
 	return
 }
+
+// All returns an iterator over index-value pairs of the underlying array of the heap, with index 0 being the top.
+// The values are not yielded in heap order.
+// The heap must not be modified during the iteration.
+func (heap *Heap[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, x := range heap.value {
+			if !yield(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the heap in the order of the underlying array.
+// The values are not yielded in heap order.
+// The heap must not be modified during the iteration.
+func (heap *Heap[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range heap.value {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements of the heap in heap order.
+// Each element is removed before it is yielded, so breaking out of the loop leaves the remaining elements in the heap.
+func (heap *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(heap.value) > 0 {
+			if !yield(heap.removeAt(0)) {
+				return
+			}
+		}
+	}
+}
@@ -5,11 +5,15 @@
 package heap
 
 import (
+	"errors"
 	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
 	"testing"
 	"time"
+
+	"github.com/GitSteve1025/containers"
 )
 
 func same(t *testing.T, left any, right any) {
@@ -66,6 +70,9 @@ func TestMakeHeap(t *testing.T) {
 }
 
 func TestBigData(t *testing.T) {
+	if debug {
+		t.Skip("the invariant checks of containers_debug make it quadratic")
+	}
 	cmp := func(a int, b int) bool {
 		return a < b
 	}
@@ -88,6 +95,9 @@ func TestBigData(t *testing.T) {
 }
 
 func TestEfficiency(t *testing.T) {
+	if debug {
+		t.Skip("the invariant checks of containers_debug make it quadratic")
+	}
 	cmp := func(a int, b int) bool {
 		return a < b
 	}
@@ -108,3 +118,234 @@ func TestEfficiency(t *testing.T) {
 	}
 	t.Log("Pop", N, "digits costs", time.Since(startTime))
 }
+
+func TestHandle(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	heap := NewWithData(cmp, 5, 3, 8)
+	a := heap.PushHandle(4)
+	b := heap.PushHandle(7)
+	heap.Push(1)
+	same(t, heap.Contains(a), true)
+	same(t, heap.Contains(b), true)
+	same(t, heap.Contains(nil), false)
+
+	heap.Update(b, 0)
+	same(t, heap.Top(), 0)
+	heap.Update(a, 10)
+	same(t, heap.Remove(a), 10)
+	same(t, heap.Contains(a), false)
+	same(t, heap.Remove(a), 0) // default value
+	heap.Update(a, -1)         // nothing to do
+
+	same(t, heap.Pop(), 0)
+	same(t, heap.Contains(b), false)
+	for _, x := range []int{1, 3, 5, 8} {
+		same(t, heap.Pop(), x)
+	}
+
+	other := New(cmp)
+	c := other.PushHandle(1)
+	same(t, heap.Contains(c), false)
+	same(t, heap.Remove(c), 0)
+	same(t, other.Size(), 1)
+}
+
+func TestHandleRandom(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	const N = 10000
+	heap := New(cmp)
+	handles := make(map[*Handle]int)
+	for i := 0; i < N; i++ {
+		switch rand.Intn(4) {
+		case 0:
+			value := rand.Intn(1000)
+			handles[heap.PushHandle(value)] = value
+		case 1:
+			for handle := range handles {
+				value := rand.Intn(1000)
+				heap.Update(handle, value)
+				handles[handle] = value
+				break
+			}
+		case 2:
+			for handle, value := range handles {
+				same(t, heap.Remove(handle), value)
+				delete(handles, handle)
+				break
+			}
+		case 3:
+			heap.Push(rand.Intn(1000))
+		}
+		if heap.handles != nil {
+			same(t, len(heap.value), len(heap.handles))
+		}
+	}
+
+	var expect []int
+	for _, value := range handles {
+		expect = append(expect, value)
+	}
+	sort.Ints(expect)
+	for !heap.Empty() {
+		top := heap.handles[0]
+		value := heap.Pop()
+		if top != nil {
+			same(t, value, expect[0])
+			expect = expect[1:]
+			same(t, heap.Contains(top), false)
+		}
+	}
+	same(t, len(expect), 0)
+}
+
+func TestFixAndRemoveAt(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	heap := NewWithData(cmp, 1, 2, 3, 4, 5, 6, 7)
+	same(t, *heap.At(0), 1)
+	same(t, heap.At(-1) == nil, true)
+	same(t, heap.At(7) == nil, true)
+
+	*heap.At(0) = 10 // sift down
+	heap.Fix(0)
+	same(t, heap.Top(), 2)
+	*heap.At(6) = 0 // sift up
+	heap.Fix(6)
+	same(t, heap.Top(), 0)
+	heap.Fix(100) // nothing to do
+
+	same(t, heap.RemoveAt(100), 0) // default value
+	same(t, heap.RemoveAt(0), 0)
+	for _, x := range []int{2, 3, 4, 5, 6, 10} {
+		same(t, heap.Pop(), x)
+	}
+}
+
+func TestFixAndRemoveAtRandom(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	const N = 10000
+	heap := New(cmp)
+	var expect []int
+	for i := 0; i < N; i++ {
+		heap.Push(rand.Intn(1000))
+	}
+	for i := 0; i < N; i++ {
+		pos := rand.Intn(heap.Size())
+		*heap.At(pos) = rand.Intn(1000)
+		heap.Fix(pos)
+	}
+	for i := 0; i < N/2; i++ {
+		heap.RemoveAt(rand.Intn(heap.Size()))
+	}
+	for i := 0; i < heap.Size(); i++ {
+		expect = append(expect, *heap.At(i))
+	}
+
+	sort.Ints(expect)
+	for i := range expect {
+		same(t, heap.Pop(), expect[i])
+	}
+	same(t, heap.Empty(), true)
+}
+
+func TestIterator(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	heap := NewWithData(cmp, 5, 3, 1, 4, 2)
+	values := slices.Collect(heap.Values())
+	same(t, len(values), 5)
+	for i, x := range heap.All() {
+		same(t, x, values[i])
+	}
+	same(t, values[0], 1)
+	slices.Sort(values)
+	same(t, values, []int{1, 2, 3, 4, 5})
+
+	var drained []int
+	for x := range heap.Drain() {
+		drained = append(drained, x)
+		if x == 3 {
+			break
+		}
+	}
+	same(t, drained, []int{1, 2, 3})
+	same(t, heap.Size(), 2)
+	same(t, slices.Collect(heap.Drain()), []int{4, 5})
+	same(t, heap.Empty(), true)
+}
+
+func TestFromSeq(t *testing.T) {
+	cmp := func(a int, b int) bool {
+		return a < b
+	}
+
+	heap := FromSeq(cmp, slices.Values([]int{5, 3, 1, 4, 2}))
+	same(t, heap.Size(), 5)
+	same(t, heap.Top(), 1)
+
+	// fewer values than the heap holds, pushed one by one.
+	heap.PushSeq(slices.Values([]int{0, 6}))
+	// more values than the heap holds, the heap is rebuilt.
+	handle := heap.PushHandle(-1)
+	heap.PushSeq(slices.Values([]int{7, 8, 9, 10, 11, 12, 13, 14, 15}))
+	same(t, heap.Remove(handle), -1)
+
+	same(t, slices.Collect(heap.Drain()), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+}
+
+func TestChecked(t *testing.T) {
+	heap := New(func(left int, right int) bool {
+		return left < right
+	})
+	_, err := heap.TopE()
+	same(t, errors.Is(err, ErrEmpty), true)
+	_, err = heap.PopE()
+	same(t, errors.Is(err, containers.ErrEmpty), true)
+
+	handle := heap.PushHandle(5)
+	heap.Push(3)
+	heap.Push(8)
+	top, err := heap.TopE()
+	same(t, top, 3)
+	same(t, err, nil)
+	_, err = heap.AtE(3)
+	same(t, errors.Is(err, ErrOutOfRange), true)
+	x, err := heap.AtE(0)
+	same(t, *x, 3)
+	*x = 10
+	same(t, heap.FixE(0), nil)
+	same(t, errors.Is(heap.FixE(-1), ErrOutOfRange), true)
+	same(t, heap.UpdateE(handle, 1), nil)
+	same(t, heap.Top(), 1)
+
+	value, err := heap.RemoveE(handle)
+	same(t, value, 1)
+	same(t, err, nil)
+	_, err = heap.RemoveE(handle)
+	same(t, errors.Is(err, ErrForeignElement), true)
+	same(t, errors.Is(heap.UpdateE(handle, 2), ErrForeignElement), true)
+	same(t, errors.Is(heap.UpdateE(nil, 2), ErrForeignElement), true)
+
+	_, err = heap.RemoveAtE(2)
+	same(t, errors.Is(err, ErrOutOfRange), true)
+	value, err = heap.RemoveAtE(1)
+	same(t, value, 10)
+	same(t, err, nil)
+	value, err = heap.PopE()
+	same(t, value, 8)
+	same(t, err, nil)
+	same(t, heap.Empty(), true)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package lfu implements a least frequently used cache on top of list.List.
+//
+// The entries are grouped in buckets by their number of accesses. The buckets are kept in a list
+// in ascending order of frequency, and each bucket holds its entries in a list from the most
+// to the least recently used. An access moves the entry to the bucket of the next frequency with
+// list.List.SpliceElement, and the least recently used entry of the first bucket is evicted
+// when the cache is full. All operations are O(1).
+package lfu
+
+import (
+	"iter"
+
+	"github.com/GitSteve1025/containers/list"
+)
+
+// entry is an entry of the cache.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	// the bucket holding the entry.
+	bucket *list.Element[bucket[K, V]]
+}
+
+// bucket holds the entries accessed frequency times.
+type bucket[K comparable, V any] struct {
+	frequency int
+	// from the most to the least recently used.
+	entries *list.List[entry[K, V]]
+}
+
+// Cache is a least frequently used cache, the least recently used entry is evicted among
+// the least frequently used ones.
+// A Cache is not safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	// in ascending order of frequency, no bucket is empty.
+	buckets  *list.List[bucket[K, V]]
+	items    map[K]*list.Element[entry[K, V]]
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// New creates an empty Cache[K, V] which holds at most capacity entries.
+// New panics if capacity is not positive.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lfu: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		buckets:  list.New[bucket[K, V]](),
+		items:    make(map[K]*list.Element[entry[K, V]]),
+		capacity: capacity,
+	}
+}
+
+// SetOnEvict sets a function which is called with every entry evicted because the cache is full.
+// A nil onEvict removes the function.
+// Entries removed by Remove, Clear or replaced by Put are not reported.
+func (cache *Cache[K, V]) SetOnEvict(onEvict func(key K, value V)) {
+	cache.onEvict = onEvict
+}
+
+// Size returns the number of entries in the cache.
+func (cache *Cache[K, V]) Size() int {
+	return len(cache.items)
+}
+
+// Capacity returns the maximum number of entries.
+func (cache *Cache[K, V]) Capacity() int {
+	return cache.capacity
+}
+
+// touch moves e to the bucket of the next frequency.
+func (cache *Cache[K, V]) touch(e *list.Element[entry[K, V]]) {
+	current := e.Value.bucket
+	next := current.Next()
+	if next == nil || next.Value.frequency != current.Value.frequency+1 {
+		next = cache.buckets.InsertAfter(bucket[K, V]{
+			frequency: current.Value.frequency + 1,
+			entries:   list.New[entry[K, V]](),
+		}, current)
+	}
+	next.Value.entries.SpliceElement(next.Value.entries.Front(), current.Value.entries, e)
+	e.Value.bucket = next
+	if current.Value.entries.Empty() {
+		cache.buckets.Erase(current)
+	}
+}
+
+// remove removes e from the cache.
+func (cache *Cache[K, V]) remove(e *list.Element[entry[K, V]]) {
+	b := e.Value.bucket
+	b.Value.entries.Erase(e)
+	if b.Value.entries.Empty() {
+		cache.buckets.Erase(b)
+	}
+	delete(cache.items, e.Value.key)
+}
+
+// Get returns the value of key and true, and increments the frequency of the entry.
+// If the cache does not contain key, Get will return the default value of V and false.
+func (cache *Cache[K, V]) Get(key K) (value V, ok bool) {
+	if e, found := cache.items[key]; found {
+		cache.touch(e)
+		return e.Value.value, true
+	}
+	return
+}
+
+// Peek returns the value of key and true, without incrementing the frequency of the entry.
+// If the cache does not contain key, Peek will return the default value of V and false.
+func (cache *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	if e, found := cache.items[key]; found {
+		return e.Value.value, true
+	}
+	return
+}
+
+// Contains returns true if the cache contains key.
+// Contains does not increment the frequency of the entry.
+func (cache *Cache[K, V]) Contains(key K) bool {
+	_, ok := cache.items[key]
+	return ok
+}
+
+// Frequency returns the number of times key has been put or got since it entered the cache,
+// or 0 if the cache does not contain key.
+func (cache *Cache[K, V]) Frequency(key K) int {
+	if e, ok := cache.items[key]; ok {
+		return e.Value.bucket.Value.frequency
+	}
+	return 0
+}
+
+// Put puts key with value.
+// If the cache already contains key, its value is replaced and its frequency is incremented.
+// Otherwise, if the cache is full, the least recently used of the least frequently used entries is evicted,
+// and key enters the cache with a frequency of 1.
+func (cache *Cache[K, V]) Put(key K, value V) {
+	if e, ok := cache.items[key]; ok {
+		e.Value.value = value
+		cache.touch(e)
+		return
+	}
+
+	if len(cache.items) >= cache.capacity {
+		victim := cache.buckets.Front().Value.entries.Back()
+		cache.remove(victim)
+		if cache.onEvict != nil {
+			cache.onEvict(victim.Value.key, victim.Value.value)
+		}
+	}
+	first := cache.buckets.Front()
+	if first == nil || first.Value.frequency != 1 {
+		first = cache.buckets.PushFront(bucket[K, V]{
+			frequency: 1,
+			entries:   list.New[entry[K, V]](),
+		})
+	}
+	cache.items[key] = first.Value.entries.PushFront(entry[K, V]{key: key, value: value, bucket: first})
+}
+
+// Remove removes key from the cache and returns true.
+// If the cache does not contain key, the cache is not modified and Remove returns false.
+func (cache *Cache[K, V]) Remove(key K) bool {
+	if e, ok := cache.items[key]; ok {
+		cache.remove(e)
+		return true
+	}
+	return false
+}
+
+// Clear removes all entries of the cache.
+func (cache *Cache[K, V]) Clear() {
+	cache.buckets.Clear()
+	clear(cache.items)
+}
+
+// All returns an iterator over the key-value pairs of the cache, from the most to the least frequently used,
+// and from the most to the least recently used among the same frequency. The frequencies are not incremented.
+// The cache must not be modified during the iteration.
+func (cache *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for b := cache.buckets.Back(); b != nil; b = b.Prev() {
+			for e := b.Value.entries.Front(); e != nil; e = e.Next() {
+				if !yield(e.Value.key, e.Value.value) {
+					return
+				}
+			}
+		}
+	}
+}
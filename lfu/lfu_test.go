@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package lfu
+
+import (
+	"maps"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+// verify checks that the buckets are sorted, not empty, and hold every entry of the map.
+func verify[K comparable, V any](t *testing.T, cache *Cache[K, V]) {
+	t.Helper()
+	count, frequency := 0, 0
+	for b := cache.buckets.Front(); b != nil; b = b.Next() {
+		if b.Value.frequency <= frequency || b.Value.entries.Empty() {
+			t.Fatal("bucket of frequency", b.Value.frequency, "is invalid")
+		}
+		frequency = b.Value.frequency
+		for e := b.Value.entries.Front(); e != nil; e = e.Next() {
+			if e.Value.bucket != b || cache.items[e.Value.key] != e {
+				t.Fatal("entry", e.Value.key, "is invalid")
+			}
+			count++
+		}
+	}
+	same(t, count, len(cache.items))
+	if count > cache.capacity {
+		t.Fatal("the cache is over its capacity")
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := New[string, int](3)
+	var evicted []string
+	cache.SetOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+	value, ok := cache.Peek("c") // not counted
+	same(t, value, 3)
+	same(t, ok, true)
+	same(t, cache.Contains("c"), true)
+	same(t, []int{cache.Frequency("a"), cache.Frequency("b"), cache.Frequency("c"), cache.Frequency("d")}, []int{3, 2, 1, 0})
+	verify(t, cache)
+
+	cache.Put("d", 4) // c is the least frequently used
+	same(t, evicted, []string{"c"})
+	cache.Put("e", 5) // d and e have the same frequency, d is the least recently used
+	same(t, evicted, []string{"c", "d"})
+	cache.Put("e", 50) // replaced, not evicted
+	same(t, cache.Frequency("e"), 2)
+	cache.Put("f", 6)
+	same(t, evicted, []string{"c", "d", "b"}) // b and e have the frequency 2, b is older
+	_, ok = cache.Get("b")
+	same(t, ok, false)
+	same(t, maps.Collect(cache.All()), map[string]int{"a": 1, "e": 50, "f": 6})
+	same(t, cache.Size(), 3)
+	same(t, cache.Capacity(), 3)
+	verify(t, cache)
+
+	same(t, cache.Remove("a"), true)
+	same(t, cache.Remove("a"), false)
+	verify(t, cache)
+	cache.Clear()
+	same(t, cache.Size(), 0)
+	same(t, len(evicted), 3)
+	verify(t, cache)
+	for range cache.All() {
+		t.Fatal("the cache is not empty")
+	}
+}
+
+func TestCacheRandom(t *testing.T) {
+	cache := New[int, int](100)
+	frequencies := make(map[int]int)
+	cache.SetOnEvict(func(key int, value int) {
+		// the evicted entry is the least frequently used.
+		for k, f := range frequencies {
+			if f < frequencies[key] {
+				t.Fatal("evicted", key, "of frequency", frequencies[key], "instead of", k, "of frequency", f)
+			}
+		}
+		delete(frequencies, key)
+	})
+	for i := 0; i < 20000; i++ {
+		k := int(rand.ExpFloat64() * 100)
+		switch rand.Intn(10) {
+		case 0:
+			same(t, cache.Remove(k), frequencies[k] > 0)
+			delete(frequencies, k)
+		case 1, 2, 3:
+			cache.Put(k, k)
+			frequencies[k]++
+		default:
+			if value, ok := cache.Get(k); ok {
+				same(t, value, k)
+				frequencies[k]++
+			} else {
+				same(t, frequencies[k], 0)
+			}
+		}
+	}
+	verify(t, cache)
+	for k, f := range frequencies {
+		same(t, cache.Frequency(k), f)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New with capacity 0 must panic")
+		}
+	}()
+	New[int, int](0)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/GitSteve1025/containers"
+)
+
+// Errors returned by the checked methods, they are the errors of package containers.
+var (
+	ErrOutOfRange     = containers.ErrOutOfRange
+	ErrForeignElement = containers.ErrForeignElement
+	ErrEmpty          = containers.ErrEmpty
+)
+
+// foreign returns an error for an element passed to op which does not belong to the list.
+func foreign(op string) error {
+	return fmt.Errorf("list: %s: %w", op, ErrForeignElement)
+}
+
+// FrontE is like Front, but returns an error wrapping ErrEmpty if the list is empty.
+func (list *List[T]) FrontE() (*Element[T], error) {
+	if list.size > 0 {
+		return list.root.next, nil
+	}
+	return nil, fmt.Errorf("list: front: %w", ErrEmpty)
+}
+
+// BackE is like Back, but returns an error wrapping ErrEmpty if the list is empty.
+func (list *List[T]) BackE() (*Element[T], error) {
+	if list.size > 0 {
+		return list.root.prev, nil
+	}
+	return nil, fmt.Errorf("list: back: %w", ErrEmpty)
+}
+
+// PopBackE is like PopBack, but returns an error wrapping ErrEmpty if the list is empty.
+func (list *List[T]) PopBackE() (value T, err error) {
+	if list.size > 0 {
+		return list.PopBack(), nil
+	}
+	return value, fmt.Errorf("list: pop back: %w", ErrEmpty)
+}
+
+// PopFrontE is like PopFront, but returns an error wrapping ErrEmpty if the list is empty.
+func (list *List[T]) PopFrontE() (value T, err error) {
+	if list.size > 0 {
+		return list.PopFront(), nil
+	}
+	return value, fmt.Errorf("list: pop front: %w", ErrEmpty)
+}
+
+// InsertBeforeE is like InsertBefore, but returns an error wrapping ErrForeignElement if at is not an element of list.
+func (list *List[T]) InsertBeforeE(val T, at *Element[T]) (*Element[T], error) {
+	if at.list() != list {
+		return nil, foreign("insert before")
+	}
+	return list.InsertBefore(val, at), nil
+}
+
+// InsertAfterE is like InsertAfter, but returns an error wrapping ErrForeignElement if at is not an element of list.
+func (list *List[T]) InsertAfterE(val T, at *Element[T]) (*Element[T], error) {
+	if at.list() != list {
+		return nil, foreign("insert after")
+	}
+	return list.InsertAfter(val, at), nil
+}
+
+// EraseE is like Erase, but returns an error wrapping ErrForeignElement if at is not an element of list.
+func (list *List[T]) EraseE(at *Element[T]) (value T, err error) {
+	if at.list() != list {
+		return value, foreign("erase")
+	}
+	return list.Erase(at), nil
+}
+
+// MoveToFrontE is like MoveToFront, but returns an error wrapping ErrForeignElement if e is not an element of list.
+func (list *List[T]) MoveToFrontE(e *Element[T]) error {
+	if e.list() != list {
+		return foreign("move to front")
+	}
+	list.MoveToFront(e)
+	return nil
+}
+
+// MoveToBackE is like MoveToBack, but returns an error wrapping ErrForeignElement if e is not an element of list.
+func (list *List[T]) MoveToBackE(e *Element[T]) error {
+	if e.list() != list {
+		return foreign("move to back")
+	}
+	list.MoveToBack(e)
+	return nil
+}
+
+// MoveBeforeE is like MoveBefore, but returns an error wrapping ErrForeignElement if e or at is not an element of list.
+// Moving e before itself is not an error.
+func (list *List[T]) MoveBeforeE(e *Element[T], at *Element[T]) error {
+	if e.list() != list || at.list() != list {
+		return foreign("move before")
+	}
+	list.MoveBefore(e, at)
+	return nil
+}
+
+// MoveAfterE is like MoveAfter, but returns an error wrapping ErrForeignElement if e or at is not an element of list.
+// Moving e after itself is not an error.
+func (list *List[T]) MoveAfterE(e *Element[T], at *Element[T]) error {
+	if e.list() != list || at.list() != list {
+		return foreign("move after")
+	}
+	list.MoveAfter(e, at)
+	return nil
+}
+
+// SpliceBeforeE is like SpliceBefore, but returns an error wrapping ErrForeignElement if at is not nil
+// and not an element of list. Splicing a list into itself is not an error.
+func (list *List[T]) SpliceBeforeE(at *Element[T], other *List[T]) error {
+	if _, ok := list.position(at); !ok {
+		return foreign("splice")
+	}
+	list.SpliceBefore(at, other)
+	return nil
+}
+
+// SpliceElementE is like SpliceElement, but returns an error wrapping ErrForeignElement if at is not nil
+// and not an element of list, or e is not an element of other. Moving e before itself is not an error.
+func (list *List[T]) SpliceElementE(at *Element[T], other *List[T], e *Element[T]) error {
+	if _, ok := list.position(at); !ok || e.list() != other {
+		return foreign("splice element")
+	}
+	list.SpliceElement(at, other, e)
+	return nil
+}
+
+// SpliceRangeE is like SpliceRange, but returns an error wrapping ErrForeignElement if at is not nil
+// and not an element of list, or first or last is not an element of other,
+// and an error wrapping ErrOutOfRange if last comes before first or at is in the range.
+func (list *List[T]) SpliceRangeE(at *Element[T], other *List[T], first *Element[T], last *Element[T]) error {
+	position, ok := list.position(at)
+	end, endOk := other.position(last)
+	if !ok || !endOk || first.list() != other {
+		return foreign("splice range")
+	}
+	for e := first; e != end; e = e.next {
+		if e == &other.root || e == position {
+			return fmt.Errorf("list: splice range: invalid range: %w", ErrOutOfRange)
+		}
+	}
+	list.SpliceRange(at, other, first, last)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package list
+
+import "fmt"
+
+// debug is true when the containers_debug build tag is set.
+// The invariants of the list are then checked after every mutation, which costs O(n).
+const debug = true
+
+// check panics with a dump of the list if the prev and next pointers are not symmetric,
+// an element does not belong to the list, or the size does not match the number of elements.
+func (list *List[T]) check() {
+	if list.root.next == nil {
+		if list.root.prev != nil || list.size != 0 {
+			list.fail("the sentinel is half initialized", nil)
+		}
+		return
+	}
+	count := 0
+	for e := &list.root; ; e = e.next {
+		if e.next == nil || e.next.prev != e {
+			list.fail(fmt.Sprintf("element %d: next.prev is not the element", count), e)
+		}
+		if e.next == &list.root {
+			break
+		}
+		count++
+		if e.next.list() != list {
+			list.fail(fmt.Sprintf("element %d belongs to another list", count), e.next)
+		}
+		if count > list.size {
+			list.fail("more elements than the size", e.next)
+		}
+	}
+	if count != list.size {
+		list.fail(fmt.Sprintf("%d elements", count), nil)
+	}
+}
+
+// fail panics with reason, the offending element if any, and a dump of the list.
+func (list *List[T]) fail(reason string, e *Element[T]) {
+	const limit = 32
+	values := "["
+	n := 0
+	for x := list.root.next; x != nil && x != &list.root && n < limit; x = x.next {
+		if n > 0 {
+			values += " "
+		}
+		values += fmt.Sprint(x.Value)
+		n++
+	}
+	if n == limit {
+		values += " ..."
+	}
+	values += "]"
+	msg := fmt.Sprintf("list: invariant violated: %s\nsize: %d\nvalues: %s", reason, list.size, values)
+	if e != nil {
+		msg += fmt.Sprintf("\nelement: %p %v, prev: %p, next: %p", e, e.Value, e.prev, e.next)
+	}
+	panic(msg)
+}
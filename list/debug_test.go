@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package list
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustPanic runs f and checks that it panics with a message containing want.
+func mustPanic(t *testing.T, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, want) {
+			t.Fatalf("got panic %q, want a panic containing %q", msg, want)
+		}
+	}()
+	f()
+}
+
+func TestDebugCheck(t *testing.T) {
+	list := NewWithData(1, 2, 3)
+	list.size++
+	mustPanic(t, "4 elements", func() { list.PushBack(4) })
+
+	list = NewWithData(1, 2, 3)
+	list.Front().Next().prev = &list.root
+	mustPanic(t, "next.prev is not the element", func() { list.PopBack() })
+
+	list = NewWithData(1, 2, 3)
+	other := NewWithData(4)
+	list.Front().cell = other.cell
+	mustPanic(t, "belongs to another list", func() { list.MoveToFront(list.Back()) })
+
+	list = NewWithData(1, 2, 3)
+	list.size--
+	mustPanic(t, "more elements than the size", func() { list.Reverse() })
+}
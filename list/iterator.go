@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package list
+
+// Iterator is a bidirectional iterator over a list, it is a position in the list.
+// The end position is past the last element, and moving forward from it wraps around to the first element.
+// An iterator stays valid as long as the element it points to is in the list.
+// Iterators are values: the methods return new iterators and never modify the receiver.
+type Iterator[T any] struct {
+	list *List[T]
+	e    *Element[T]
+}
+
+// Begin returns an iterator to the first element of the list.
+func (list *List[T]) Begin() Iterator[T] {
+	return Iterator[T]{list, list.root.next}
+}
+
+// End returns an iterator past the last element of the list.
+func (list *List[T]) End() Iterator[T] {
+	return Iterator[T]{list, &list.root}
+}
+
+// IteratorOf returns an iterator to e, a nil e means End().
+// If e is not an element of list, IteratorOf returns End().
+func (list *List[T]) IteratorOf(e *Element[T]) Iterator[T] {
+	if e == nil || e.list() != list {
+		return list.End()
+	}
+	return Iterator[T]{list, e}
+}
+
+// Element returns the element the iterator points to, or nil at the end.
+func (it Iterator[T]) Element() *Element[T] {
+	if it.e == &it.list.root {
+		return nil
+	}
+	return it.e
+}
+
+// Deref returns a reference to the value of the element the iterator points to.
+// Deref will return nil at the end.
+func (it Iterator[T]) Deref() *T {
+	if it.e == &it.list.root {
+		return nil
+	}
+	return &it.e.Value
+}
+
+// Next returns an iterator to the next element.
+func (it Iterator[T]) Next() Iterator[T] {
+	return Iterator[T]{it.list, it.e.next}
+}
+
+// Prev returns an iterator to the previous element.
+func (it Iterator[T]) Prev() Iterator[T] {
+	return Iterator[T]{it.list, it.e.prev}
+}
+
+// Equal returns true if it and other point to the same position.
+func (it Iterator[T]) Equal(other Iterator[T]) bool {
+	return it.e == other.e
+}
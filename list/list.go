@@ -11,11 +11,24 @@
 //	}
 //
 //	// reverse
-//	for e := l.Back(); e != nil; e = l.Prev() {
+//	for e := l.Back(); e != nil; e = e.Prev() {
 //	 	// do something with e.Value
 //	}
+//
+// Or with range-over-func iterators:
+//
+//	for v := range l.Values() {
+//		// do something with v
+//	}
+//
+//	// e may be erased inside the loop
+//	for e := range l.Elements() {
+//		// do something with e
+//	}
 package list
 
+import "iter"
+
 type Element[T any] struct {
 	// The value stored in this element.
 	Value T
@@ -23,19 +36,46 @@ type Element[T any] struct {
 	prev *Element[T]
 	// next pointer of the element.
 	next *Element[T]
-	// The list to which this element belongs.
+	// The cell of the list to which this element belongs, nil if the element is not in a list.
+	cell *cell[T]
+}
+
+// cell records the list owning a group of elements, so that the owner of a whole list can change in O(1).
+// Every list has its own cell. When SpliceBefore or Merge moves all elements of other,
+// the cell of other is chained to the cell of the destination, and other gets a new cell.
+type cell[T any] struct {
+	// The owning list, nil once the cell has been chained.
 	list *List[T]
+	// The cell which took over the elements, nil while the cell belongs to a list.
+	parent *cell[T]
+}
+
+// list returns the list to which e belongs, or nil.
+// The chain of cells is compressed, so the amortized time complexity is nearly O(1).
+func (e *Element[T]) list() *List[T] {
+	if e.cell == nil {
+		return nil
+	}
+	root := e.cell
+	for root.parent != nil {
+		root = root.parent
+	}
+	for c := e.cell; c != root; {
+		c, c.parent = c.parent, root
+	}
+	e.cell = root
+	return root.list
 }
 
 func (e *Element[T]) Prev() *Element[T] {
-	if p := e.prev; e.list != nil && p != &e.list.root {
+	if p, list := e.prev, e.list(); list != nil && p != &list.root {
 		return p
 	}
 	return nil
 }
 
 func (e *Element[T]) Next() *Element[T] {
-	if n := e.next; e.list != nil && n != &e.list.root {
+	if n, list := e.next, e.list(); list != nil && n != &list.root {
 		return n
 	}
 	return nil
@@ -47,12 +87,15 @@ type List[T any] struct {
 	root Element[T]
 	// length of list.
 	size int
+	// the cell of the list, referred to by its elements.
+	cell *cell[T]
 }
 
 // New creates a new empty List[T].
 func New[T any]() *List[T] {
 	list := new(List[T])
 	list.init()
+	list.cell = &cell[T]{list: list}
 	return list
 }
 
@@ -66,6 +109,14 @@ func NewWithData[T any](data ...T) *List[T] {
 	return list
 }
 
+// FromSeq creates a list which contains the values of seq.
+// Values will be placed in the order in which seq yields them.
+func FromSeq[T any](seq iter.Seq[T]) *List[T] {
+	list := New[T]()
+	list.AppendSeq(seq)
+	return list
+}
+
 // init initializes or clears list.
 func (list *List[T]) init() {
 	list.root.prev = &list.root
@@ -105,10 +156,11 @@ func (list *List[T]) Back() *Element[T] {
 func (list *List[T]) insert(v *Element[T], at *Element[T]) *Element[T] {
 	v.prev = at.prev
 	v.next = at
-	v.list = list
+	v.cell = list.cell
 	v.prev.next = v
 	v.next.prev = v
 	list.size++
+	list.check()
 	return v
 }
 
@@ -127,11 +179,18 @@ func (list *List[T]) PushFront(val T) *Element[T] {
 	return list.insertValue(val, list.root.next)
 }
 
+// AppendSeq adds the values of seq to the end of the list.
+func (list *List[T]) AppendSeq(seq iter.Seq[T]) {
+	for x := range seq {
+		list.insertValue(x, &list.root)
+	}
+}
+
 // InsertBefore inserts val before at, and return the new element.
 // If at is not an element of list, the list is not modified.
 // The at must not be nil.
 func (list *List[T]) InsertBefore(val T, at *Element[T]) *Element[T] {
-	if at.list == list {
+	if at.list() == list {
 		return list.insertValue(val, at)
 	}
 	return nil
@@ -141,7 +200,7 @@ func (list *List[T]) InsertBefore(val T, at *Element[T]) *Element[T] {
 // If at is not an element of list, the list is not modified.
 // The at must not be nil.
 func (list *List[T]) InsertAfter(val T, at *Element[T]) *Element[T] {
-	if at.list == list {
+	if at.list() == list {
 		return list.insertValue(val, at.next)
 	}
 	return nil
@@ -153,8 +212,9 @@ func (list *List[T]) erase(at *Element[T]) {
 	at.next.prev = at.prev
 	at.prev = nil // avoid memory leaks
 	at.next = nil // avoid memory leaks
-	at.list = nil
+	at.cell = nil
 	list.size--
+	list.check()
 }
 
 // PopBack removes last element and returns the value of the element.
@@ -183,7 +243,7 @@ func (list *List[T]) PopFront() (value T) {
 // If at is not an element of list, the list is not modified, it will return at.Value.
 // The at must not be nil.
 func (list *List[T]) Erase(at *Element[T]) T {
-	if at.list == list {
+	if at.list() == list {
 		list.erase(at)
 	}
 	return at.Value
@@ -208,13 +268,14 @@ func (list *List[T]) move(e *Element[T], at *Element[T]) {
 	e.next = at
 	e.prev.next = e
 	e.next.prev = e
+	list.check()
 }
 
 // MoveToFront moves element e to the front of list.
 // If e is not an element of list, the list is not modified.
 // The element must not be nil.
 func (list *List[T]) MoveToFront(e *Element[T]) {
-	if e.list != list || list.root.next == e {
+	if e.list() != list || list.root.next == e {
 		return
 	}
 	list.move(e, list.root.next)
@@ -224,7 +285,7 @@ func (list *List[T]) MoveToFront(e *Element[T]) {
 // If e is not an element of list, the list is not modified.
 // The element must not be nil.
 func (list *List[T]) MoveToBack(e *Element[T]) {
-	if e.list != list || list.root.prev == e {
+	if e.list() != list || list.root.prev == e {
 		return
 	}
 	list.move(e, &list.root)
@@ -234,7 +295,7 @@ func (list *List[T]) MoveToBack(e *Element[T]) {
 // If e or at is not an element of list, or e == at, the list is not modified.
 // The element and at must not be nil.
 func (list *List[T]) MoveBefore(e *Element[T], at *Element[T]) {
-	if e.list != list || at.list != list || e == at {
+	if e.list() != list || at.list() != list || e == at {
 		return
 	}
 	list.move(e, at)
@@ -244,8 +305,286 @@ func (list *List[T]) MoveBefore(e *Element[T], at *Element[T]) {
 // If e or at is not an element of list, or e == at, the list is not modified.
 // The element and at must not be nil.
 func (list *List[T]) MoveAfter(e *Element[T], at *Element[T]) {
-	if e.list != list || at.list != list || e == at {
+	if e.list() != list || at.list() != list || e == at {
 		return
 	}
 	list.move(e, at.next)
 }
+
+// splice moves the chain from first to last of other in front of at, n is the length of the chain.
+// The elements must already belong to list.
+func (list *List[T]) splice(at *Element[T], other *List[T], first *Element[T], last *Element[T], n int) {
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	other.size -= n
+
+	first.prev = at.prev
+	last.next = at
+	first.prev.next = first
+	at.prev = last
+	list.size += n
+	list.check()
+	other.check()
+}
+
+// position returns at, or &list.root when at is nil, and whether it is a position in list.
+func (list *List[T]) position(at *Element[T]) (*Element[T], bool) {
+	if at == nil {
+		return &list.root, true
+	}
+	return at, at.list() == list
+}
+
+// adopt makes all elements of other belong to list in O(1) by chaining the cell of other to the cell of list,
+// and gives other a new cell. The elements are not relinked.
+func (list *List[T]) adopt(other *List[T]) {
+	other.cell.list, other.cell.parent = nil, list.cell
+	other.cell = &cell[T]{list: other}
+}
+
+// SpliceBefore moves all elements of other in front of at with a time complexity of O(1),
+// a nil at means the end of list. Other becomes empty.
+// The elements are relinked rather than copied, they become elements of list.
+// If at is not an element of list, or other is list, the lists are not modified.
+func (list *List[T]) SpliceBefore(at *Element[T], other *List[T]) {
+	at, ok := list.position(at)
+	if !ok || other == list || other.size == 0 {
+		return
+	}
+	list.adopt(other)
+	list.splice(at, other, other.root.next, other.root.prev, other.size)
+}
+
+// SpliceElement moves element e of other in front of at, a nil at means the end of list.
+// Other may be list.
+// If at is not an element of list, e is not an element of other, or e == at, the lists are not modified.
+// The element must not be nil.
+func (list *List[T]) SpliceElement(at *Element[T], other *List[T], e *Element[T]) {
+	at, ok := list.position(at)
+	if !ok || e.list() != other || e == at {
+		return
+	}
+	e.cell = list.cell
+	list.splice(at, other, e, e, 1)
+}
+
+// SpliceRange moves the elements of other in [first, last) in front of at, a nil at means the end of list,
+// and a nil last means the end of other. The time complexity is linear in the number of moved elements.
+// Other may be list, in which case at must not be in [first, last).
+// If at is not an element of list, first or last is not an element of other, last comes before first,
+// or at is in the range, the lists are not modified.
+// The first must not be nil.
+func (list *List[T]) SpliceRange(at *Element[T], other *List[T], first *Element[T], last *Element[T]) {
+	at, ok := list.position(at)
+	end, endOk := other.position(last)
+	if !ok || !endOk || first.list() != other || first == end {
+		return
+	}
+	n := 0
+	for e := first; e != end; e = e.next {
+		if e == &other.root || e == at {
+			return
+		}
+		n++
+	}
+	for e := first; e != end; e = e.next {
+		e.cell = list.cell
+	}
+	list.splice(at, other, first, end.prev, n)
+}
+
+// Reverse reverses the order of the elements in place.
+func (list *List[T]) Reverse() {
+	e := &list.root
+	for {
+		e.prev, e.next = e.next, e.prev
+		e = e.prev
+		if e == &list.root {
+			list.check()
+			return
+		}
+	}
+}
+
+// mergeChains merges the sorted chains a and b, which are linked by next and end with nil.
+// It returns the head of the merged chain, elements of a come before the equivalent elements of b.
+// mergeChains, mergeSort and relink mirror those of package forwardlist, a fix to one belongs in both.
+func mergeChains[T any](a *Element[T], b *Element[T], less func(left T, right T) bool) *Element[T] {
+	var head Element[T]
+	tail := &head
+	for a != nil && b != nil {
+		if less(b.Value, a.Value) {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	return head.next
+}
+
+// mergeSort sorts the first n elements of the chain starting at head, with n > 0.
+// It returns the head of the sorted chain, which ends with nil, and the rest of the chain.
+func mergeSort[T any](head *Element[T], n int, less func(left T, right T) bool) (*Element[T], *Element[T]) {
+	if n == 1 {
+		rest := head.next
+		head.next = nil
+		return head, rest
+	}
+	a, rest := mergeSort(head, n/2, less)
+	b, rest := mergeSort(rest, n-n/2, less)
+	return mergeChains(a, b, less), rest
+}
+
+// relink makes the chain starting at head, which is linked by next and ends with nil, the elements of list.
+// The prev pointers are rebuilt and the chain is closed with the sentinel.
+func (list *List[T]) relink(head *Element[T]) {
+	prev := &list.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &list.root
+	list.root.prev = prev
+}
+
+// Sort sorts the list in ascending order according to less with a time complexity of O(n log n).
+// The sort is stable. The elements are relinked rather than copied, so they remain valid.
+// Less must not be nil.
+func (list *List[T]) Sort(less func(left T, right T) bool) {
+	if list.size < 2 {
+		return
+	}
+	head, _ := mergeSort(list.root.next, list.size, less)
+	list.relink(head)
+	list.check()
+}
+
+// Merge merges the sorted other into the sorted list in linear time. Other becomes empty.
+// Elements of list come before the equivalent elements of other.
+// The elements of other are relinked rather than copied, they become elements of list.
+// If other is list, the list is not modified.
+// Less must not be nil.
+func (list *List[T]) Merge(other *List[T], less func(left T, right T) bool) {
+	if other == list || other.size == 0 {
+		return
+	}
+	list.adopt(other)
+	// terminate both chains with nil.
+	list.root.prev.next = nil
+	other.root.prev.next = nil
+	var a *Element[T]
+	if list.size > 0 {
+		a = list.root.next
+	}
+	list.relink(mergeChains(a, other.root.next, less))
+	list.size += other.size
+	other.init()
+	list.check()
+}
+
+// Unique removes all but the first element from every group of consecutive equivalent elements,
+// and returns the number of removed elements.
+// Eq is called with the first element of the group and the element to check.
+// Eq must not be nil.
+func (list *List[T]) Unique(eq func(left T, right T) bool) int {
+	removed := 0
+	if list.size < 2 {
+		return removed
+	}
+	first := list.root.next
+	for e := first.next; e != &list.root; {
+		next := e.next
+		if eq(first.Value, e.Value) {
+			list.erase(e)
+			removed++
+		} else {
+			first = e
+		}
+		e = next
+	}
+	return removed
+}
+
+// RemoveIf removes all elements whose values satisfy pred, and returns the number of removed elements.
+// Pred must not be nil.
+func (list *List[T]) RemoveIf(pred func(value T) bool) int {
+	removed := 0
+	for e := list.root.next; e != &list.root; {
+		next := e.next
+		if pred(e.Value) {
+			list.erase(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// Remove removes all elements of list which are equal to value, and returns the number of removed elements.
+func Remove[T comparable](list *List[T], value T) int {
+	return list.RemoveIf(func(v T) bool {
+		return v == value
+	})
+}
+
+// All returns an iterator over index-value pairs of the list from front to back.
+func (list *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := list.Front(); e != nil; e = e.Next() {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list from front to back.
+func (list *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := list.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs of the list, traversing it from back to front with descending indices.
+func (list *List[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := list.size - 1
+		for e := list.Back(); e != nil; e = e.Prev() {
+			if !yield(i, e.Value) {
+				return
+			}
+			i--
+		}
+	}
+}
+
+// Elements returns an iterator over the elements of the list from front to back.
+// The current element may be erased or moved during the iteration, the iteration continues with the element that followed it.
+// If that element is erased as well, the iteration stops.
+func (list *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := list.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			if next == nil || next.list() != list {
+				return
+			}
+			e = next
+		}
+	}
+}
@@ -5,8 +5,14 @@
 package list
 
 import (
+	"errors"
+	"maps"
+	"math/rand"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/GitSteve1025/containers"
 )
 
 func TestNew(t *testing.T) {
@@ -312,6 +318,9 @@ func TestAllFunction(t *testing.T) {
 }
 
 func TestPushPopEfficiency(t *testing.T) {
+	if debug {
+		t.Skip("the invariant checks of containers_debug make it quadratic")
+	}
 	const N = 30000000
 	list := New[int]()
 	{
@@ -346,3 +355,383 @@ func TestPushPopEfficiency(t *testing.T) {
 		t.Log("Popfront", N, " digits costs ", time.Since(start))
 	}
 }
+
+func TestIterator(t *testing.T) {
+	list := NewWithData(1, 2, 3, 4, 5)
+
+	var index []int
+	var values []int
+	for i, x := range list.All() {
+		index = append(index, i)
+		values = append(values, x)
+	}
+	if !slices.Equal(index, []int{0, 1, 2, 3, 4}) || !slices.Equal(values, []int{1, 2, 3, 4, 5}) {
+		t.Error("All is invalid", index, values)
+	}
+
+	if !slices.Equal(slices.Collect(list.Values()), []int{1, 2, 3, 4, 5}) {
+		t.Error("Values is invalid")
+	}
+
+	index = index[:0]
+	values = values[:0]
+	for i, x := range list.Backward() {
+		if i == 1 {
+			break
+		}
+		index = append(index, i)
+		values = append(values, x)
+	}
+	if !slices.Equal(index, []int{4, 3, 2}) || !slices.Equal(values, []int{5, 4, 3}) {
+		t.Error("Backward is invalid", index, values)
+	}
+}
+
+func TestElements(t *testing.T) {
+	list := NewWithData(1, 2, 3, 4, 5, 6)
+
+	// erase the current element during the iteration.
+	var values []int
+	for e := range list.Elements() {
+		values = append(values, e.Value)
+		if e.Value%2 == 0 {
+			list.Erase(e)
+		}
+	}
+	if !slices.Equal(values, []int{1, 2, 3, 4, 5, 6}) {
+		t.Error("Elements is invalid", values)
+	}
+	if !slices.Equal(slices.Collect(list.Values()), []int{1, 3, 5}) {
+		t.Error("erase during Elements is invalid")
+	}
+
+	// move the current element to the back during the iteration.
+	values = values[:0]
+	for e := range list.Elements() {
+		values = append(values, e.Value)
+		if e.Value == 1 {
+			list.MoveToBack(e)
+		}
+	}
+	if !slices.Equal(values, []int{1, 3, 5, 1}) {
+		t.Error("move during Elements is invalid", values)
+	}
+
+	// the next element is erased during the iteration.
+	values = values[:0]
+	for e := range list.Elements() {
+		values = append(values, e.Value)
+		list.Erase(e.Next())
+	}
+	if !slices.Equal(values, []int{3}) {
+		t.Error("erase next during Elements is invalid", values)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	list := FromSeq(slices.Values([]int{1, 2, 3}))
+	if list.Size() != 3 || !slices.Equal(slices.Collect(list.Values()), []int{1, 2, 3}) {
+		t.Error("FromSeq is invalid")
+	}
+
+	list.AppendSeq(NewWithData(4, 5).Values())
+	if list.Size() != 5 || !slices.Equal(slices.Collect(list.Values()), []int{1, 2, 3, 4, 5}) {
+		t.Error("AppendSeq is invalid")
+	}
+
+	keys := FromSeq(maps.Keys(map[string]int{"a": 1, "b": 2}))
+	if !slices.Equal(slices.Sorted(keys.Values()), []string{"a", "b"}) {
+		t.Error("FromSeq of maps.Keys is invalid")
+	}
+}
+
+// checkList checks the values, the size, the links and the ownership of the elements of list.
+func checkList(t *testing.T, list *List[int], values ...int) {
+	t.Helper()
+	if list.Size() != len(values) || !slices.Equal(slices.Collect(list.Values()), values) {
+		t.Fatal("list", slices.Collect(list.Values()), "is not", values)
+	}
+	var backward []int
+	for e := list.Back(); e != nil; e = e.Prev() {
+		if e.list() != list {
+			t.Fatal("element", e.Value, "does not belong to the list")
+		}
+		backward = append([]int{e.Value}, backward...)
+	}
+	if !slices.Equal(backward, values) {
+		t.Fatal("backward links are invalid", backward)
+	}
+}
+
+func TestSplice(t *testing.T) {
+	pending := NewWithData(1, 2, 3)
+	running := NewWithData(10, 20)
+
+	running.SpliceBefore(running.Back(), pending)
+	checkList(t, running, 10, 1, 2, 3, 20)
+	checkList(t, pending)
+	pending.SpliceBefore(nil, NewWithData(4, 5))
+	checkList(t, pending, 4, 5)
+	running.SpliceBefore(nil, pending)
+	checkList(t, running, 10, 1, 2, 3, 20, 4, 5)
+	running.SpliceBefore(nil, running)
+	pending.SpliceBefore(running.Front(), NewWithData(6))
+	checkList(t, running, 10, 1, 2, 3, 20, 4, 5)
+	checkList(t, pending)
+
+	// one element
+	e := running.Front()
+	pending.SpliceElement(nil, running, e)
+	checkList(t, pending, 10)
+	checkList(t, running, 1, 2, 3, 20, 4, 5)
+	running.SpliceElement(running.Front(), running, running.Back())
+	checkList(t, running, 5, 1, 2, 3, 20, 4)
+	running.SpliceElement(running.Front().Next(), running, running.Front())
+	checkList(t, running, 5, 1, 2, 3, 20, 4)
+	running.SpliceElement(nil, running, e) // e is not an element of running
+	running.SpliceElement(e, pending, e)   // at is not an element of running
+	pending.SpliceElement(e, pending, e)   // e == at
+	checkList(t, running, 5, 1, 2, 3, 20, 4)
+	checkList(t, pending, 10)
+
+	// a range
+	first := running.Front().Next()
+	last := first.Next().Next().Next()
+	pending.SpliceRange(pending.Front(), running, first, last)
+	checkList(t, pending, 1, 2, 3, 10)
+	checkList(t, running, 5, 20, 4)
+	pending.SpliceRange(nil, running, running.Front().Next(), nil)
+	checkList(t, pending, 1, 2, 3, 10, 20, 4)
+	checkList(t, running, 5)
+	pending.SpliceRange(pending.Front(), pending, pending.Front().Next(), pending.Back())
+	checkList(t, pending, 2, 3, 10, 20, 1, 4)
+	pending.SpliceRange(nil, pending, pending.Front(), pending.Back())
+	checkList(t, pending, 4, 2, 3, 10, 20, 1)
+
+	// invalid ranges
+	pending.SpliceRange(nil, pending, pending.Back(), pending.Front())
+	pending.SpliceRange(pending.Back(), pending, pending.Front(), nil)
+	running.SpliceRange(nil, pending, running.Front(), nil)
+	pending.SpliceRange(nil, pending, pending.Front(), running.Front())
+	checkList(t, pending, 4, 2, 3, 10, 20, 1)
+	checkList(t, running, 5)
+}
+
+func TestSpliceOwnership(t *testing.T) {
+	a, b, c := NewWithData(1, 2), NewWithData(3), NewWithData(4)
+	first := a.Front()
+	b.SpliceBefore(nil, a)
+	c.SpliceBefore(c.Front(), b)
+	checkList(t, a)
+	checkList(t, b)
+	checkList(t, c, 3, 1, 2, 4)
+
+	// the emptied lists own the elements pushed afterwards, and not the moved ones.
+	e := a.PushBack(5)
+	a.Erase(first)
+	b.MoveToFront(first)
+	checkList(t, c, 3, 1, 2, 4)
+	c.MoveToBack(first)
+	checkList(t, c, 3, 2, 4, 1)
+	checkList(t, a, 5)
+
+	// the lists keep working after their cells are chained several times.
+	a.SpliceBefore(e, c)
+	c.PushBack(6)
+	a.SpliceBefore(nil, c)
+	checkList(t, a, 3, 2, 4, 1, 5, 6)
+	a.SpliceElement(nil, a, first)
+	if c.Erase(first) != 1 || a.Erase(first) != 1 {
+		t.Fatal("Erase returned a wrong value")
+	}
+	checkList(t, a, 3, 2, 4, 5, 6)
+	if first.Next() != nil || first.Prev() != nil {
+		t.Fatal("an erased element must not have neighbours")
+	}
+}
+
+func TestSortAndMerge(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		values := make([]int, n)
+		elements := make(map[*Element[int]]int)
+		list := New[int]()
+		for i := range values {
+			values[i] = rand.Intn(10)*1000 + i // the thousands are the keys, the rest is the order
+			elements[list.PushBack(values[i])] = values[i]
+		}
+		less := func(left int, right int) bool {
+			return left/1000 < right/1000
+		}
+		list.Sort(less)
+		slices.Sort(values)
+		checkList(t, list, values...)
+
+		others := make([]int, rand.Intn(30))
+		other := New[int]()
+		for i := range others {
+			others[i] = rand.Intn(10)*1000 + n + i
+			elements[other.PushBack(others[i])] = others[i]
+		}
+		other.Sort(less)
+		list.Merge(other, less)
+		values = append(values, others...)
+		slices.Sort(values)
+		checkList(t, list, values...)
+		checkList(t, other)
+		list.Merge(list, less)
+		checkList(t, list, values...)
+
+		// the elements held by the caller are still valid.
+		for e, v := range elements {
+			if e.Value != v || e.list() != list {
+				t.Fatal("element", v, "is invalid")
+			}
+		}
+	}
+}
+
+func TestUniqueReverseRemove(t *testing.T) {
+	list := NewWithData(1, 1, 2, 3, 3, 3, 1, 4, 4)
+	if list.Unique(func(left int, right int) bool { return left == right }) != 4 {
+		t.Error("Unique returns a wrong count")
+	}
+	checkList(t, list, 1, 2, 3, 1, 4)
+	// equivalent to the first of the group
+	list = NewWithData(1, 2, 3, 4, 6, 7)
+	if list.Unique(func(left int, right int) bool { return right-left <= 2 }) != 3 {
+		t.Error("Unique returns a wrong count")
+	}
+	checkList(t, list, 1, 4, 7)
+
+	list.Reverse()
+	checkList(t, list, 7, 4, 1)
+	New[int]().Reverse()
+	list.PushBack(4)
+	list.PushFront(1)
+	checkList(t, list, 1, 7, 4, 1, 4)
+	e := list.Back()
+	if Remove(list, 4) != 2 || e.list() != nil {
+		t.Error("Remove is invalid")
+	}
+	checkList(t, list, 1, 7, 1)
+	if list.RemoveIf(func(v int) bool { return v < 5 }) != 2 {
+		t.Error("RemoveIf returns a wrong count")
+	}
+	checkList(t, list, 7)
+	if Remove(list, 8) != 0 || list.Unique(nil) != 0 {
+		t.Error("nothing should be removed")
+	}
+	checkList(t, list, 7)
+}
+
+func TestChecked(t *testing.T) {
+	list := New[int]()
+	other := NewWithData(7, 8, 9)
+	if _, err := list.FrontE(); !errors.Is(err, ErrEmpty) {
+		t.Error("FrontE of an empty list is invalid", err)
+	}
+	if _, err := list.BackE(); !errors.Is(err, containers.ErrEmpty) {
+		t.Error("BackE of an empty list is invalid", err)
+	}
+	if _, err := list.PopBackE(); !errors.Is(err, ErrEmpty) {
+		t.Error("PopBackE of an empty list is invalid", err)
+	}
+	if _, err := list.PopFrontE(); !errors.Is(err, ErrEmpty) {
+		t.Error("PopFrontE of an empty list is invalid", err)
+	}
+
+	foreign := other.Front()
+	if _, err := list.InsertBeforeE(1, foreign); !errors.Is(err, ErrForeignElement) {
+		t.Error("InsertBeforeE of a foreign element is invalid", err)
+	}
+	if _, err := list.InsertAfterE(1, foreign); !errors.Is(err, ErrForeignElement) {
+		t.Error("InsertAfterE of a foreign element is invalid", err)
+	}
+	if _, err := list.EraseE(foreign); !errors.Is(err, ErrForeignElement) {
+		t.Error("EraseE of a foreign element is invalid", err)
+	}
+	if list.MoveToFrontE(foreign) == nil || list.MoveToBackE(foreign) == nil {
+		t.Error("moving a foreign element is not an error")
+	}
+	checkList(t, other, 7, 8, 9)
+
+	two := list.PushBack(2)
+	one, err := list.InsertBeforeE(1, two)
+	if err != nil {
+		t.Error(err)
+	}
+	three, err := list.InsertAfterE(3, two)
+	if err != nil {
+		t.Error(err)
+	}
+	if list.MoveBeforeE(three, foreign) == nil || list.MoveAfterE(foreign, one) == nil {
+		t.Error("moving around a foreign element is not an error")
+	}
+	if list.MoveBeforeE(three, one) != nil || list.MoveAfterE(one, two) != nil || list.MoveToBackE(two) != nil || list.MoveToFrontE(three) != nil {
+		t.Error("moving an element is an error")
+	}
+	checkList(t, list, 3, 1, 2)
+	if value, err := list.EraseE(one); value != 1 || err != nil {
+		t.Error("EraseE is invalid", err)
+	}
+
+	if list.SpliceBeforeE(foreign, other) == nil || list.SpliceElementE(nil, list, foreign) == nil {
+		t.Error("splicing a foreign element is not an error")
+	}
+	if err := list.SpliceRangeE(nil, other, other.Back(), other.Front()); !errors.Is(err, ErrOutOfRange) {
+		t.Error("SpliceRangeE of a reversed range is invalid", err)
+	}
+	if err := list.SpliceRangeE(nil, list, list.Front(), list.Back()); err != nil {
+		t.Error(err)
+	}
+	if err := list.SpliceRangeE(list.Back(), list, list.Front(), nil); !errors.Is(err, ErrOutOfRange) {
+		t.Error("SpliceRangeE into the range is invalid", err)
+	}
+	if err := list.SpliceElementE(nil, other, foreign); err != nil {
+		t.Error(err)
+	}
+	if err := list.SpliceBeforeE(list.Front(), other); err != nil {
+		t.Error(err)
+	}
+	checkList(t, list, 8, 9, 2, 3, 7)
+	checkList(t, other)
+
+	front, _ := list.FrontE()
+	back, _ := list.BackE()
+	first, _ := list.PopFrontE()
+	last, _ := list.PopBackE()
+	if front.Value != 8 || back.Value != 7 || first != 8 || last != 7 {
+		t.Error("checked functions are invalid")
+	}
+}
+
+func TestBeginEnd(t *testing.T) {
+	list := NewWithData(1, 2, 3)
+	begin, end := list.Begin(), list.End()
+	if end.Deref() != nil || end.Element() != nil || begin.Element() != list.Front() {
+		t.Error("Deref or Element is invalid")
+	}
+	if !end.Next().Equal(begin) || !begin.Prev().Equal(end) || !end.Prev().Equal(list.IteratorOf(list.Back())) {
+		t.Error("Next or Prev is invalid")
+	}
+	if !list.IteratorOf(nil).Equal(end) || !list.IteratorOf(New[int]().PushBack(1)).Equal(end) {
+		t.Error("IteratorOf is invalid")
+	}
+	var values []int
+	for it := begin; !it.Equal(end); it = it.Next() {
+		*it.Deref() *= 10
+		values = append(values, *it.Deref())
+	}
+	checkList(t, list, 10, 20, 30)
+	if !slices.Equal(values, []int{10, 20, 30}) {
+		t.Error("iteration is invalid", values)
+	}
+
+	// an iterator to an element stays valid when other elements are inserted.
+	it := begin.Next()
+	list.PushFront(0)
+	list.InsertBefore(15, it.Element())
+	if *it.Deref() != 20 || *it.Prev().Deref() != 15 {
+		t.Error("iterator after insertion is invalid")
+	}
+}
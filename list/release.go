@@ -0,0 +1,13 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build !containers_debug
+
+package list
+
+// debug is true when the containers_debug build tag is set, see debug.go.
+const debug = false
+
+// check does nothing without the containers_debug build tag, the call is inlined away.
+func (list *List[T]) check() {}
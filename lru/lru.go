@@ -0,0 +1,259 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package lru implements a least recently used cache on top of list.List.
+//
+// The entries are kept in a list from the most to the least recently used, and a map finds the element of a key.
+// Get and Put move the entry to the front of the list, and the entries at the back are evicted
+// when the cache is over its capacity. All operations are O(1).
+//
+// The capacity limits the number of entries, or their total weight for a cache created by NewWithWeight.
+// Entries may also expire after a time-to-live set by SetTTL.
+package lru
+
+import (
+	"iter"
+	"time"
+
+	"github.com/GitSteve1025/containers/list"
+)
+
+// entry is an entry of the cache.
+type entry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight int
+	// the zero time means the entry never expires.
+	expires time.Time
+}
+
+// Cache is a least recently used cache.
+// A Cache is not safe for concurrent use, see SyncCache.
+type Cache[K comparable, V any] struct {
+	// from the most to the least recently used.
+	order    *list.List[entry[K, V]]
+	items    map[K]*list.Element[entry[K, V]]
+	capacity int
+	weight   int
+	weigh    func(key K, value V) int
+	onEvict  func(key K, value V)
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// New creates an empty Cache[K, V] which holds at most capacity entries.
+// New panics if capacity is not positive.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithWeight[K, V](capacity, nil)
+}
+
+// NewWithWeight creates an empty Cache[K, V] whose entries weigh at most capacity in total.
+// The weight of an entry is given by weigh when it is put, a nil weigh gives every entry a weight of 1.
+// Weigh must return a positive weight, Put panics otherwise.
+// NewWithWeight panics if capacity is not positive.
+func NewWithWeight[K comparable, V any](capacity int, weigh func(key K, value V) int) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lru: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		order:    list.New[entry[K, V]](),
+		items:    make(map[K]*list.Element[entry[K, V]]),
+		capacity: capacity,
+		weigh:    weigh,
+		now:      time.Now,
+	}
+}
+
+// SetOnEvict sets a function which is called with every entry evicted because the cache is over its capacity,
+// or because the entry has expired. A nil onEvict removes the function.
+// Entries removed by Remove, Clear or replaced by Put are not reported.
+func (cache *Cache[K, V]) SetOnEvict(onEvict func(key K, value V)) {
+	cache.onEvict = onEvict
+}
+
+// SetTTL sets the time-to-live of the entries put afterwards, a non positive ttl means they never expire.
+// Expired entries are not returned, and are evicted when they are found or by RemoveExpired.
+func (cache *Cache[K, V]) SetTTL(ttl time.Duration) {
+	cache.ttl = ttl
+}
+
+// Size returns the number of entries in the cache, including the expired entries not evicted yet.
+func (cache *Cache[K, V]) Size() int {
+	return cache.order.Size()
+}
+
+// Weight returns the total weight of the entries in the cache.
+func (cache *Cache[K, V]) Weight() int {
+	return cache.weight
+}
+
+// Capacity returns the maximum number of entries, or the maximum total weight of the entries.
+func (cache *Cache[K, V]) Capacity() int {
+	return cache.capacity
+}
+
+// expired returns true if e has expired.
+func (cache *Cache[K, V]) expired(e *list.Element[entry[K, V]]) bool {
+	return !e.Value.expires.IsZero() && !cache.now().Before(e.Value.expires)
+}
+
+// remove removes e from the cache.
+func (cache *Cache[K, V]) remove(e *list.Element[entry[K, V]]) {
+	cache.order.Erase(e)
+	delete(cache.items, e.Value.key)
+	cache.weight -= e.Value.weight
+}
+
+// evict removes e from the cache and reports it to onEvict.
+func (cache *Cache[K, V]) evict(e *list.Element[entry[K, V]]) {
+	cache.remove(e)
+	if cache.onEvict != nil {
+		cache.onEvict(e.Value.key, e.Value.value)
+	}
+}
+
+// lookup returns the element of key, or nil if the cache does not contain key or the entry has expired.
+// The expired entry is evicted.
+func (cache *Cache[K, V]) lookup(key K) *list.Element[entry[K, V]] {
+	e, ok := cache.items[key]
+	if !ok {
+		return nil
+	}
+	if cache.expired(e) {
+		cache.evict(e)
+		return nil
+	}
+	return e
+}
+
+// Get returns the value of key and true, and marks the entry as the most recently used.
+// If the cache does not contain key or the entry has expired, Get will return the default value of V and false.
+func (cache *Cache[K, V]) Get(key K) (value V, ok bool) {
+	if e := cache.lookup(key); e != nil {
+		cache.order.MoveToFront(e)
+		return e.Value.value, true
+	}
+	return
+}
+
+// Peek returns the value of key and true, without marking the entry as used.
+// If the cache does not contain key or the entry has expired, Peek will return the default value of V and false.
+// Peek does not modify the cache.
+func (cache *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	if e, found := cache.items[key]; found && !cache.expired(e) {
+		return e.Value.value, true
+	}
+	return
+}
+
+// Contains returns true if the cache contains key and the entry has not expired.
+// Contains does not mark the entry as used.
+func (cache *Cache[K, V]) Contains(key K) bool {
+	_, ok := cache.Peek(key)
+	return ok
+}
+
+// Put puts key with value as the most recently used entry, replacing the previous value of key.
+// Then the least recently used entries are evicted while the cache is over its capacity,
+// so an entry heavier than the capacity is evicted at once.
+// Put panics if the weight of the entry is not positive, the cache is then not modified.
+func (cache *Cache[K, V]) Put(key K, value V) {
+	item := entry[K, V]{key: key, value: value, weight: 1}
+	if cache.weigh != nil {
+		item.weight = cache.weigh(key, value)
+		if item.weight <= 0 {
+			panic("lru: weight must be positive")
+		}
+	}
+	if cache.ttl > 0 {
+		item.expires = cache.now().Add(cache.ttl)
+	}
+
+	if e, ok := cache.items[key]; ok {
+		cache.weight += item.weight - e.Value.weight
+		e.Value = item
+		cache.order.MoveToFront(e)
+	} else {
+		cache.items[key] = cache.order.PushFront(item)
+		cache.weight += item.weight
+	}
+	cache.shrink()
+}
+
+// shrink evicts the least recently used entries while the cache is over its capacity,
+// and returns the number of evicted entries.
+func (cache *Cache[K, V]) shrink() int {
+	evicted := 0
+	for cache.weight > cache.capacity && !cache.order.Empty() {
+		cache.evict(cache.order.Back())
+		evicted++
+	}
+	return evicted
+}
+
+// Remove removes key from the cache and returns true.
+// If the cache does not contain key, the cache is not modified and Remove returns false.
+func (cache *Cache[K, V]) Remove(key K) bool {
+	if e, ok := cache.items[key]; ok {
+		cache.remove(e)
+		return true
+	}
+	return false
+}
+
+// RemoveExpired evicts all expired entries and returns their number.
+func (cache *Cache[K, V]) RemoveExpired() int {
+	evicted := 0
+	for e := range cache.order.Elements() {
+		if cache.expired(e) {
+			cache.evict(e)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// Resize sets the capacity, evicts the least recently used entries while the cache is over it,
+// and returns the number of evicted entries.
+// Resize panics if capacity is not positive.
+func (cache *Cache[K, V]) Resize(capacity int) int {
+	if capacity <= 0 {
+		panic("lru: capacity must be positive")
+	}
+	cache.capacity = capacity
+	return cache.shrink()
+}
+
+// Clear removes all entries of the cache.
+func (cache *Cache[K, V]) Clear() {
+	cache.order.Clear()
+	clear(cache.items)
+	cache.weight = 0
+}
+
+// All returns an iterator over the key-value pairs of the cache which have not expired,
+// from the most to the least recently used. The entries are not marked as used.
+// The cache must not be modified during the iteration.
+func (cache *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := cache.order.Front(); e != nil; e = e.Next() {
+			if !cache.expired(e) && !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the cache which have not expired,
+// from the most to the least recently used. The entries are not marked as used.
+// The cache must not be modified during the iteration.
+func (cache *Cache[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range cache.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package lru
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := New[string, int](3)
+	var evicted []string
+	cache.SetOnEvict(func(key string, value int) {
+		evicted = append(evicted, key+"="+strconv.Itoa(value))
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	same(t, slices.Collect(cache.Keys()), []string{"c", "b", "a"})
+	value, ok := cache.Get("a")
+	same(t, value, 1)
+	same(t, ok, true)
+	value, ok = cache.Peek("b") // not promoted
+	same(t, value, 2)
+	same(t, ok, true)
+	same(t, cache.Contains("b"), true)
+	cache.Put("d", 4)
+	same(t, evicted, []string{"b=2"})
+	same(t, slices.Collect(cache.Keys()), []string{"d", "a", "c"})
+	_, ok = cache.Get("b")
+	same(t, ok, false)
+	_, ok = cache.Peek("b")
+	same(t, ok, false)
+
+	cache.Put("c", 30) // replaced, not evicted
+	same(t, evicted, []string{"b=2"})
+	same(t, maps.Collect(cache.All()), map[string]int{"a": 1, "c": 30, "d": 4})
+	same(t, cache.Size(), 3)
+	same(t, cache.Weight(), 3)
+
+	same(t, cache.Resize(1), 2)
+	same(t, evicted, []string{"b=2", "a=1", "d=4"})
+	same(t, cache.Capacity(), 1)
+	same(t, slices.Collect(cache.Keys()), []string{"c"})
+
+	same(t, cache.Remove("c"), true)
+	same(t, cache.Remove("c"), false)
+	same(t, cache.Size(), 0)
+	same(t, len(evicted), 3)
+
+	cache.Resize(5)
+	cache.Put("x", 1)
+	cache.Put("y", 2)
+	cache.Clear()
+	same(t, cache.Size(), 0)
+	same(t, cache.Weight(), 0)
+	same(t, len(evicted), 3)
+	for range cache.All() {
+		t.Fatal("the cache is not empty")
+	}
+}
+
+func TestWeight(t *testing.T) {
+	cache := NewWithWeight(10, func(key string, value []byte) int {
+		return len(value)
+	})
+	var evicted []string
+	cache.SetOnEvict(func(key string, value []byte) {
+		evicted = append(evicted, key)
+	})
+	cache.Put("a", make([]byte, 4))
+	cache.Put("b", make([]byte, 4))
+	same(t, cache.Weight(), 8)
+	cache.Put("c", make([]byte, 3))
+	same(t, evicted, []string{"a"})
+	same(t, cache.Weight(), 7)
+	cache.Put("b", make([]byte, 8)) // grows, c is evicted
+	same(t, evicted, []string{"a", "c"})
+	same(t, cache.Weight(), 8)
+	cache.Put("d", make([]byte, 11)) // heavier than the capacity
+	same(t, evicted, []string{"a", "c", "b", "d"})
+	same(t, cache.Size(), 0)
+	same(t, cache.Weight(), 0)
+
+	// a weight which is not positive would let the cache grow without bound.
+	cache.Put("e", make([]byte, 2))
+	defer func() {
+		if recover() == nil {
+			t.Error("Put with weight 0 must panic")
+		}
+		same(t, cache.Weight(), 2)
+		same(t, cache.Contains("f"), false)
+	}()
+	cache.Put("f", nil)
+}
+
+func TestTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := New[int, int](10)
+	cache.now = func() time.Time { return now }
+	var evicted []int
+	cache.SetOnEvict(func(key int, value int) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put(0, 0) // never expires
+	cache.SetTTL(time.Minute)
+	cache.Put(1, 1)
+	now = now.Add(30 * time.Second)
+	cache.Put(2, 2)
+	now = now.Add(30 * time.Second)
+
+	_, ok := cache.Peek(1)
+	same(t, ok, false)
+	same(t, cache.Size(), 3) // not evicted yet
+	same(t, slices.Collect(cache.Keys()), []int{2, 0})
+	_, ok = cache.Get(1)
+	same(t, ok, false)
+	same(t, evicted, []int{1})
+	same(t, cache.Size(), 2)
+
+	cache.Put(3, 3)
+	now = now.Add(time.Hour)
+	same(t, cache.Contains(0), true)
+	same(t, cache.RemoveExpired(), 2)
+	same(t, evicted, []int{1, 3, 2})
+	same(t, slices.Collect(cache.Keys()), []int{0})
+
+	cache.SetTTL(0)
+	cache.Put(4, 4)
+	now = now.Add(time.Hour)
+	same(t, cache.Contains(4), true)
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New with capacity 0 must panic")
+		}
+	}()
+	New[int, int](0)
+}
+
+func TestSyncCache(t *testing.T) {
+	cache := NewSync[int, int](100)
+	var evicted int
+	cache.SetOnEvict(func(key int, value int) {
+		evicted++
+	})
+	cache.SetTTL(time.Hour)
+
+	var removed atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := g*1000 + i
+				cache.Put(k, k)
+				if value, ok := cache.Get(k - 1); ok && value != k-1 {
+					t.Error("Get returns a wrong value")
+				}
+				cache.Peek(k)
+				cache.Contains(k)
+				if i%10 == 0 && cache.Remove(k) {
+					removed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	same(t, cache.Size(), 100)
+	same(t, cache.Weight(), 100)
+	same(t, cache.Capacity(), 100)
+	same(t, len(cache.Keys()), 100)
+	same(t, evicted, 8*1000-int(removed.Load())-100)
+	same(t, cache.RemoveExpired(), 0)
+	same(t, cache.Resize(10), 90)
+	cache.Clear()
+	same(t, cache.Size(), 0)
+
+	weighted := NewSyncWithWeight(4, func(key string, value string) int {
+		return len(value)
+	})
+	weighted.Put("a", "abc")
+	weighted.Put("b", "de")
+	same(t, weighted.Keys(), []string{"b"})
+}
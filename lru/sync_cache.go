@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncCache is a least recently used cache that is safe for concurrent use by multiple goroutines.
+// Every method locks the whole cache, since even Get modifies the order of the entries.
+type SyncCache[K comparable, V any] struct {
+	mutex sync.Mutex
+	cache *Cache[K, V]
+}
+
+// NewSync creates an empty SyncCache[K, V] which holds at most capacity entries.
+// NewSync panics if capacity is not positive.
+func NewSync[K comparable, V any](capacity int) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: New[K, V](capacity)}
+}
+
+// NewSyncWithWeight creates an empty SyncCache[K, V] whose entries weigh at most capacity in total,
+// like NewWithWeight. Weigh must return a positive weight, Put panics otherwise.
+// NewSyncWithWeight panics if capacity is not positive.
+func NewSyncWithWeight[K comparable, V any](capacity int, weigh func(key K, value V) int) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: NewWithWeight(capacity, weigh)}
+}
+
+// SetOnEvict sets a function which is called with every evicted entry, like Cache.SetOnEvict.
+// The function is called with the cache locked, so it must not use the cache.
+func (cache *SyncCache[K, V]) SetOnEvict(onEvict func(key K, value V)) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.cache.SetOnEvict(onEvict)
+}
+
+// SetTTL sets the time-to-live of the entries put afterwards, like Cache.SetTTL.
+func (cache *SyncCache[K, V]) SetTTL(ttl time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.cache.SetTTL(ttl)
+}
+
+// Size returns the number of entries in the cache, including the expired entries not evicted yet.
+func (cache *SyncCache[K, V]) Size() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Size()
+}
+
+// Weight returns the total weight of the entries in the cache.
+func (cache *SyncCache[K, V]) Weight() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Weight()
+}
+
+// Capacity returns the maximum number of entries, or the maximum total weight of the entries.
+func (cache *SyncCache[K, V]) Capacity() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Capacity()
+}
+
+// Get returns the value of key and true, and marks the entry as the most recently used.
+// If the cache does not contain key or the entry has expired, Get will return the default value of V and false.
+func (cache *SyncCache[K, V]) Get(key K) (value V, ok bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Get(key)
+}
+
+// Peek returns the value of key and true, without marking the entry as used.
+// If the cache does not contain key or the entry has expired, Peek will return the default value of V and false.
+func (cache *SyncCache[K, V]) Peek(key K) (value V, ok bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Peek(key)
+}
+
+// Contains returns true if the cache contains key and the entry has not expired.
+func (cache *SyncCache[K, V]) Contains(key K) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Contains(key)
+}
+
+// Put puts key with value as the most recently used entry, like Cache.Put.
+func (cache *SyncCache[K, V]) Put(key K, value V) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.cache.Put(key, value)
+}
+
+// Remove removes key from the cache and returns true.
+// If the cache does not contain key, the cache is not modified and Remove returns false.
+func (cache *SyncCache[K, V]) Remove(key K) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Remove(key)
+}
+
+// RemoveExpired evicts all expired entries and returns their number.
+func (cache *SyncCache[K, V]) RemoveExpired() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.RemoveExpired()
+}
+
+// Resize sets the capacity and returns the number of evicted entries, like Cache.Resize.
+func (cache *SyncCache[K, V]) Resize(capacity int) int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.cache.Resize(capacity)
+}
+
+// Clear removes all entries of the cache.
+func (cache *SyncCache[K, V]) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.cache.Clear()
+}
+
+// Keys returns a snapshot of the keys of the cache which have not expired,
+// from the most to the least recently used.
+func (cache *SyncCache[K, V]) Keys() []K {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	keys := make([]K, 0, cache.cache.Size())
+	for k := range cache.cache.Keys() {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when putting into a closed BlockingQueue,
+// or taking from a closed BlockingQueue that has been drained.
+var ErrClosed = errors.New("queue: closed")
+
+// BlockingQueue is a bounded FIFO queue that is safe for concurrent use by multiple goroutines.
+// Put blocks while the queue is full and Take blocks while the queue is empty.
+type BlockingQueue[T any] struct {
+	mutex sync.Mutex
+	// signaled when an element is taken or the queue is closed.
+	notFull sync.Cond
+	// signaled when an element is put or the queue is closed.
+	notEmpty sync.Cond
+	queue    Queue[T]
+	capacity int
+	closed   bool
+}
+
+// NewBlocking creates an empty BlockingQueue[T] which holds at most capacity elements.
+// NewBlocking panics if capacity is not positive.
+func NewBlocking[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	queue := &BlockingQueue[T]{capacity: capacity}
+	queue.notFull.L = &queue.mutex
+	queue.notEmpty.L = &queue.mutex
+	return queue
+}
+
+// Size returns the number of elements in the queue.
+func (queue *BlockingQueue[T]) Size() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.queue.Size()
+}
+
+// Capacity returns the maximum number of elements the queue can hold.
+func (queue *BlockingQueue[T]) Capacity() int {
+	return queue.capacity
+}
+
+// Closed returns true if Close has been called.
+func (queue *BlockingQueue[T]) Closed() bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.closed
+}
+
+// Close closes the queue and wakes up every goroutine blocked in Put or Take.
+// After Close, Put fails with ErrClosed, while Take keeps returning the remaining elements
+// and fails with ErrClosed once the queue is drained.
+// Closing a closed queue does nothing.
+func (queue *BlockingQueue[T]) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if !queue.closed {
+		queue.closed = true
+		queue.notFull.Broadcast()
+		queue.notEmpty.Broadcast()
+	}
+}
+
+// wakeOnDone broadcasts cond when ctx is done, so that goroutines waiting on cond can observe ctx.Err().
+// The returned stop function must be called once the waiting is over.
+func wakeOnDone(ctx context.Context, mutex *sync.Mutex, cond *sync.Cond) (stop func() bool) {
+	if ctx.Done() == nil {
+		return func() bool { return true }
+	}
+	return context.AfterFunc(ctx, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		cond.Broadcast()
+	})
+}
+
+// Put adds value to the back of the queue, blocking while the queue is full.
+// Put returns ErrClosed if the queue is closed.
+func (queue *BlockingQueue[T]) Put(value T) error {
+	return queue.PutContext(context.Background(), value)
+}
+
+// PutContext adds value to the back of the queue, blocking while the queue is full.
+// PutContext returns ErrClosed if the queue is closed, or ctx.Err() if ctx is done before there is room.
+func (queue *BlockingQueue[T]) PutContext(ctx context.Context, value T) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	stop := wakeOnDone(ctx, &queue.mutex, &queue.notFull)
+	defer stop()
+
+	for !queue.closed && queue.queue.Size() >= queue.capacity {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		queue.notFull.Wait()
+	}
+	if queue.closed {
+		return ErrClosed
+	}
+	queue.queue.Push(value)
+	queue.notEmpty.Signal()
+	return nil
+}
+
+// TryPut adds value to the back of the queue without blocking.
+// TryPut returns false if the queue is full or closed.
+func (queue *BlockingQueue[T]) TryPut(value T) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.closed || queue.queue.Size() >= queue.capacity {
+		return false
+	}
+	queue.queue.Push(value)
+	queue.notEmpty.Signal()
+	return true
+}
+
+// Take removes the front element of the queue and returns its value, blocking while the queue is empty.
+// Take returns ErrClosed if the queue is closed and empty.
+func (queue *BlockingQueue[T]) Take() (T, error) {
+	return queue.TakeContext(context.Background())
+}
+
+// TakeContext removes the front element of the queue and returns its value, blocking while the queue is empty.
+// TakeContext returns ErrClosed if the queue is closed and empty, or ctx.Err() if ctx is done before an element arrives.
+func (queue *BlockingQueue[T]) TakeContext(ctx context.Context) (value T, err error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	stop := wakeOnDone(ctx, &queue.mutex, &queue.notEmpty)
+	defer stop()
+
+	for !queue.closed && queue.queue.Empty() {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		queue.notEmpty.Wait()
+	}
+	if queue.queue.Empty() {
+		return value, ErrClosed
+	}
+	value = queue.queue.Pop()
+	queue.notFull.Signal()
+	return value, nil
+}
+
+// TryTake removes the front element of the queue and returns its value without blocking.
+// TryTake returns the default value of T and false if the queue is empty.
+func (queue *BlockingQueue[T]) TryTake() (value T, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.queue.Empty() {
+		return
+	}
+	value = queue.queue.Pop()
+	queue.notFull.Signal()
+	return value, true
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingBasicFunction(t *testing.T) {
+	queue := NewBlocking[int](3)
+	same(t, queue.Capacity(), 3)
+	for i := 0; i < 3; i++ {
+		same(t, queue.TryPut(i), true)
+	}
+	same(t, queue.TryPut(3), false) // full
+	same(t, queue.Size(), 3)
+
+	for i := 0; i < 3; i++ {
+		value, err := queue.Take()
+		same(t, err, nil)
+		same(t, value, i)
+	}
+	value, ok := queue.TryTake()
+	same(t, ok, false) // empty
+	same(t, value, 0)  // default value
+}
+
+func TestBlockingNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBlocking with zero capacity must panic")
+		}
+	}()
+	NewBlocking[int](0)
+}
+
+func TestBlockingClose(t *testing.T) {
+	queue := NewBlocking[int](2)
+	same(t, queue.Put(1), nil)
+	queue.Close()
+	queue.Close() // nothing to do
+	same(t, queue.Closed(), true)
+
+	same(t, queue.Put(2), ErrClosed)
+	same(t, queue.TryPut(2), false)
+
+	// the remaining element can still be taken.
+	value, err := queue.Take()
+	same(t, err, nil)
+	same(t, value, 1)
+
+	_, err = queue.Take()
+	same(t, errors.Is(err, ErrClosed), true)
+}
+
+func TestBlockingCloseWakesWaiters(t *testing.T) {
+	const N = 8
+	empty := NewBlocking[int](1)
+	full := NewBlocking[int](1)
+	full.Put(0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*N)
+	for i := 0; i < N; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := empty.Take()
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- full.Put(1)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond) // let the goroutines block
+	empty.Close()
+	full.Close()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		same(t, err, ErrClosed)
+	}
+}
+
+func TestBlockingContext(t *testing.T) {
+	queue := NewBlocking[int](1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.TakeContext(ctx)
+	same(t, err, context.DeadlineExceeded)
+
+	queue.Put(1)
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	same(t, queue.PutContext(ctx, 2), context.Canceled)
+
+	// a done context does not prevent an operation that does not need to wait.
+	value, err := queue.TakeContext(ctx)
+	same(t, err, nil)
+	same(t, value, 1)
+}
+
+func TestBlockingProducerConsumer(t *testing.T) {
+	const producers = 8
+	const consumers = 8
+	const N = 10000
+
+	queue := NewBlocking[int](16)
+	var producerGroup sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producerGroup.Add(1)
+		go func() {
+			defer producerGroup.Done()
+			for i := 0; i < N; i++ {
+				if err := queue.Put(1); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	sums := make(chan int, consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			sum := 0
+			for {
+				value, err := queue.Take()
+				if err != nil {
+					sums <- sum
+					return
+				}
+				sum += value
+			}
+		}()
+	}
+
+	producerGroup.Wait()
+	queue.Close()
+	total := 0
+	for c := 0; c < consumers; c++ {
+		total += <-sums
+	}
+	same(t, total, producers*N)
+}
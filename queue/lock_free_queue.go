@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import "sync/atomic"
+
+// cacheLineSize is used to keep the head and the tail of LockFreeQueue on different cache lines.
+const cacheLineSize = 64
+
+// cell is a slot of LockFreeQueue.
+// sequence tells which position of the queue the cell is ready for:
+// sequence == pos means it is free for the Push at pos,
+// sequence == pos+1 means it holds the value pushed at pos.
+type cell[T any] struct {
+	sequence atomic.Uint64
+	value    T
+}
+
+// LockFreeQueue is a bounded multi-producer/multi-consumer FIFO queue that is safe for concurrent use
+// without locks. It is the sequence-numbered ring described by Dmitry Vyukov:
+// producers and consumers only contend on a single atomic counter each, and never block.
+//
+// LockFreeQueue suits hot paths with many goroutines where a mutex-guarded queue is a bottleneck.
+// Use BlockingQueue when goroutines need to wait for room or for elements.
+type LockFreeQueue[T any] struct {
+	_ [cacheLineSize]byte
+	// position of the next Pop.
+	head atomic.Uint64
+	_    [cacheLineSize - 8]byte
+	// position of the next Push.
+	tail atomic.Uint64
+	_    [cacheLineSize - 8]byte
+	// len(buffer) - 1, len(buffer) is a power of two.
+	mask   uint64
+	buffer []cell[T]
+}
+
+// NewLockFree creates an empty LockFreeQueue[T] which holds at most capacity elements.
+// The capacity is rounded up to a power of two.
+// NewLockFree panics if capacity is not positive.
+func NewLockFree[T any](capacity int) *LockFreeQueue[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	n := 1
+	for n < capacity {
+		n *= 2
+	}
+	queue := &LockFreeQueue[T]{
+		mask:   uint64(n - 1),
+		buffer: make([]cell[T], n),
+	}
+	for i := range queue.buffer {
+		queue.buffer[i].sequence.Store(uint64(i))
+	}
+	return queue
+}
+
+// Capacity returns the maximum number of elements the queue can hold.
+func (queue *LockFreeQueue[T]) Capacity() int {
+	return len(queue.buffer)
+}
+
+// Size returns the number of elements in the queue.
+// When other goroutines are using the queue, the result is only a snapshot.
+func (queue *LockFreeQueue[T]) Size() int {
+	for {
+		tail := queue.tail.Load()
+		head := queue.head.Load()
+		if tail == queue.tail.Load() {
+			return int(tail - head)
+		}
+	}
+}
+
+// Empty returns true if the queue is empty.
+// When other goroutines are using the queue, the result is only a snapshot.
+func (queue *LockFreeQueue[T]) Empty() bool {
+	return queue.Size() == 0
+}
+
+// Push adds value to the back of the queue.
+// Push returns false without blocking if the queue is full.
+func (queue *LockFreeQueue[T]) Push(value T) bool {
+	pos := queue.tail.Load()
+	for {
+		c := &queue.buffer[pos&queue.mask]
+		diff := int64(c.sequence.Load() - pos)
+		if diff == 0 {
+			// the cell is free, try to claim the position.
+			if queue.tail.CompareAndSwap(pos, pos+1) {
+				c.value = value
+				c.sequence.Store(pos + 1)
+				return true
+			}
+			pos = queue.tail.Load()
+		} else if diff < 0 {
+			// the cell still holds the value pushed one lap ago.
+			return false
+		} else {
+			// another producer claimed the position.
+			pos = queue.tail.Load()
+		}
+	}
+}
+
+// Pop removes the front element of the queue and returns its value.
+// Pop returns the default value of T and false without blocking if the queue is empty.
+func (queue *LockFreeQueue[T]) Pop() (value T, ok bool) {
+	pos := queue.head.Load()
+	for {
+		c := &queue.buffer[pos&queue.mask]
+		diff := int64(c.sequence.Load() - (pos + 1))
+		if diff == 0 {
+			// the cell holds a value, try to claim the position.
+			if queue.head.CompareAndSwap(pos, pos+1) {
+				var zero T
+				value = c.value
+				c.value = zero // avoid memory leaks
+				c.sequence.Store(pos + queue.mask + 1)
+				return value, true
+			}
+			pos = queue.head.Load()
+		} else if diff < 0 {
+			// the value for this position has not been pushed yet.
+			return
+		} else {
+			// another consumer claimed the position.
+			pos = queue.head.Load()
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeBasicFunction(t *testing.T) {
+	queue := NewLockFree[int](5)
+	same(t, queue.Capacity(), 8) // rounded up to a power of two
+	same(t, queue.Empty(), true)
+
+	value, ok := queue.Pop()
+	same(t, ok, false)
+	same(t, value, 0) // default value
+
+	// go around the ring a few times.
+	for lap := 0; lap < 3; lap++ {
+		for i := 0; i < 8; i++ {
+			same(t, queue.Push(i), true)
+		}
+		same(t, queue.Push(8), false) // full
+		same(t, queue.Size(), 8)
+		for i := 0; i < 8; i++ {
+			value, ok := queue.Pop()
+			same(t, ok, true)
+			same(t, value, i)
+		}
+		same(t, queue.Empty(), true)
+	}
+}
+
+func TestLockFreeConcurrent(t *testing.T) {
+	const producers = 8
+	const consumers = 8
+	const N = 10000
+
+	queue := NewLockFree[int](64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= N; i++ {
+				for !queue.Push(i) {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	sums := make(chan int, consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			sum := 0
+			for i := 0; i < producers*N/consumers; i++ {
+				value, ok := queue.Pop()
+				for !ok {
+					runtime.Gosched()
+					value, ok = queue.Pop()
+				}
+				sum += value
+			}
+			sums <- sum
+		}()
+	}
+
+	wg.Wait()
+	total := 0
+	for c := 0; c < consumers; c++ {
+		total += <-sums
+	}
+	same(t, total, producers*N*(N+1)/2)
+	same(t, queue.Empty(), true)
+}
+
+// mutexQueue is a Queue guarded by a mutex, used as a baseline in benchmarks.
+type mutexQueue[T any] struct {
+	mutex sync.Mutex
+	queue Queue[T]
+}
+
+func (queue *mutexQueue[T]) Push(value T) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queue.Push(value)
+	return true
+}
+
+func (queue *mutexQueue[T]) Pop() (value T, ok bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.queue.Empty() {
+		return
+	}
+	return queue.queue.Pop(), true
+}
+
+// channelQueue is a buffered channel, used as a baseline in benchmarks.
+type channelQueue[T any] chan T
+
+func (queue channelQueue[T]) Push(value T) bool {
+	select {
+	case queue <- value:
+		return true
+	default:
+		return false
+	}
+}
+
+func (queue channelQueue[T]) Pop() (value T, ok bool) {
+	select {
+	case value = <-queue:
+		return value, true
+	default:
+		return
+	}
+}
+
+// benchmarkQueue runs b.N Push/Pop pairs spread over the given number of goroutines.
+// Every goroutine has at most one element in flight, so the queue never overflows.
+func benchmarkQueue(b *testing.B, goroutines int, queue interface {
+	Push(int) bool
+	Pop() (int, bool)
+}) {
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for g := 0; g < goroutines; g++ {
+		n := b.N / goroutines
+		if g < b.N%goroutines {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				for !queue.Push(i) {
+					runtime.Gosched()
+				}
+				for _, ok := queue.Pop(); !ok; _, ok = queue.Pop() {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkConcurrentQueue(b *testing.B) {
+	const capacity = 1024
+	for _, goroutines := range []int{1, 4, 16} {
+		b.Run(fmt.Sprint("LockFree/", goroutines), func(b *testing.B) {
+			benchmarkQueue(b, goroutines, NewLockFree[int](capacity))
+		})
+		b.Run(fmt.Sprint("Channel/", goroutines), func(b *testing.B) {
+			benchmarkQueue(b, goroutines, make(channelQueue[int], capacity))
+		})
+		b.Run(fmt.Sprint("Mutex/", goroutines), func(b *testing.B) {
+			benchmarkQueue(b, goroutines, new(mutexQueue[int]))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import (
+	"cmp"
+
+	"github.com/GitSteve1025/containers/heap"
+)
+
+// entry is an element of PriorityQueue, seq is the insertion order used to break ties.
+type entry[T any] struct {
+	value T
+	seq   uint64
+}
+
+// PriorityQueue is an adapter over heap.Heap with the semantics of std::priority_queue:
+// Top is the greatest element according to less.
+type PriorityQueue[T any] struct {
+	heap *heap.Heap[entry[T]]
+	// insertion counter.
+	seq uint64
+}
+
+// newPriorityQueue creates an empty PriorityQueue[T] whose Top is the first element according to first.
+// If stable is true, equal elements are popped in insertion order.
+func newPriorityQueue[T any](first func(left T, right T) bool, stable bool) *PriorityQueue[T] {
+	comparator := func(left entry[T], right entry[T]) bool {
+		return first(left.value, right.value)
+	}
+	if stable {
+		comparator = func(left entry[T], right entry[T]) bool {
+			if first(left.value, right.value) {
+				return true
+			}
+			if first(right.value, left.value) {
+				return false
+			}
+			return left.seq < right.seq
+		}
+	}
+	return &PriorityQueue[T]{
+		heap: heap.New(comparator),
+	}
+}
+
+// NewPriorityQueue creates an empty PriorityQueue[T] like std::priority_queue.
+// Top is the greatest element according to less, so less(a, b) = a < b gives a max priority queue.
+// The order of equal elements is unspecified.
+// Less must not be nil.
+func NewPriorityQueue[T any](less func(left T, right T) bool) *PriorityQueue[T] {
+	return newPriorityQueue(func(left T, right T) bool { return less(right, left) }, false)
+}
+
+// NewStablePriorityQueue is like NewPriorityQueue, but equal elements are popped in insertion order (FIFO).
+// Less must not be nil.
+func NewStablePriorityQueue[T any](less func(left T, right T) bool) *PriorityQueue[T] {
+	return newPriorityQueue(func(left T, right T) bool { return less(right, left) }, true)
+}
+
+// NewMaxPriorityQueue creates an empty PriorityQueue[T] whose Top is the greatest element.
+// Equal elements are popped in insertion order if stable is true.
+func NewMaxPriorityQueue[T cmp.Ordered](stable bool) *PriorityQueue[T] {
+	return newPriorityQueue(func(left T, right T) bool { return cmp.Less(right, left) }, stable)
+}
+
+// NewMinPriorityQueue creates an empty PriorityQueue[T] whose Top is the smallest element.
+// Equal elements are popped in insertion order if stable is true.
+func NewMinPriorityQueue[T cmp.Ordered](stable bool) *PriorityQueue[T] {
+	return newPriorityQueue(cmp.Less[T], stable)
+}
+
+// Size returns the number of elements in the priority queue.
+func (queue *PriorityQueue[T]) Size() int {
+	return queue.heap.Size()
+}
+
+// Empty returns true if the priority queue is empty.
+func (queue *PriorityQueue[T]) Empty() bool {
+	return queue.heap.Empty()
+}
+
+// Push inserts value into the priority queue with a time complexity of O(log n).
+func (queue *PriorityQueue[T]) Push(value T) {
+	queue.heap.Push(entry[T]{value: value, seq: queue.seq})
+	queue.seq++
+}
+
+// Top returns the top element of the priority queue with a time complexity of O(1).
+// If the priority queue is empty, Top will return the default value of T.
+func (queue *PriorityQueue[T]) Top() T {
+	return queue.heap.Top().value
+}
+
+// Pop removes the top element of the priority queue and returns its value with a time complexity of O(log n).
+// If the priority queue is empty, Pop will return the default value of T.
+func (queue *PriorityQueue[T]) Pop() T {
+	return queue.heap.Pop().value
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueueBasicFunction(t *testing.T) {
+	queue := NewPriorityQueue(func(a int, b int) bool {
+		return a < b
+	})
+	same(t, queue.Empty(), true)
+	same(t, queue.Top(), 0) // default value
+	same(t, queue.Pop(), 0) // default value
+
+	for _, x := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		queue.Push(x)
+	}
+	same(t, queue.Size(), 8)
+	for _, x := range []int{9, 6, 5, 4, 3, 2, 1, 1} {
+		same(t, queue.Top(), x)
+		same(t, queue.Pop(), x)
+	}
+	same(t, queue.Empty(), true)
+}
+
+func TestOrderedPriorityQueue(t *testing.T) {
+	const N = 1000
+	var expect []int
+	maxQueue := NewMaxPriorityQueue[int](false)
+	minQueue := NewMinPriorityQueue[int](false)
+	for i := 0; i < N; i++ {
+		x := rand.Intn(100)
+		expect = append(expect, x)
+		maxQueue.Push(x)
+		minQueue.Push(x)
+	}
+
+	sort.Ints(expect)
+	for i := 0; i < N; i++ {
+		same(t, minQueue.Pop(), expect[i])
+		same(t, maxQueue.Pop(), expect[N-1-i])
+	}
+}
+
+func TestStablePriorityQueue(t *testing.T) {
+	type job struct {
+		priority int
+		id       int
+	}
+
+	const N = 1000
+	var expect []job
+	queue := NewStablePriorityQueue(func(a job, b job) bool {
+		return a.priority < b.priority
+	})
+	for i := 0; i < N; i++ {
+		x := job{priority: rand.Intn(10), id: i}
+		expect = append(expect, x)
+		queue.Push(x)
+	}
+
+	// highest priority first, FIFO among equal priorities.
+	sort.SliceStable(expect, func(i, j int) bool {
+		return expect[i].priority > expect[j].priority
+	})
+	for i := 0; i < N; i++ {
+		same(t, queue.Pop(), expect[i])
+	}
+
+	minQueue := NewMinPriorityQueue[string](true)
+	minQueue.Push("b")
+	minQueue.Push("a")
+	minQueue.Push("b")
+	same(t, minQueue.Pop(), "a")
+	same(t, minQueue.Pop(), "b")
+	same(t, minQueue.Pop(), "b")
+}
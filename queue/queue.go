@@ -2,4 +2,136 @@
 // This file is licensed under the MIT License.
 // See the LICENSE file in the project root for more information.
 
+// Package queue implements queues and queue adapters.
+//
+// Queue is backed by a growable circular buffer and is not safe for concurrent use.
+// BlockingQueue is a bounded queue for producer/consumer pipelines.
+// LockFreeQueue is a bounded multi-producer/multi-consumer queue which never blocks.
+// PriorityQueue is an adapter over heap.Heap like std::priority_queue.
 package queue
+
+import "iter"
+
+// minCapacity is the capacity allocated by the first Push on an empty queue.
+const minCapacity = 8
+
+type Queue[T any] struct {
+	// circular buffer, len(buffer) is always zero or a power of two.
+	buffer []T
+	// index of the first element in buffer.
+	head int
+	// number of elements in the queue.
+	size int
+}
+
+// New creates a new empty Queue[T].
+func New[T any]() *Queue[T] {
+	return new(Queue[T])
+}
+
+// NewWithData creates a queue which contains data.
+// Data will be placed in order, data[0] is the front of the queue.
+func NewWithData[T any](data ...T) *Queue[T] {
+	queue := New[T]()
+	for _, x := range data {
+		queue.Push(x)
+	}
+	return queue
+}
+
+// Size returns the number of elements in the queue.
+func (queue *Queue[T]) Size() int {
+	return queue.size
+}
+
+// Capacity returns the number of elements that the queue can hold before needing to allocate more memory.
+func (queue *Queue[T]) Capacity() int {
+	return len(queue.buffer)
+}
+
+// Empty returns true if the queue is empty.
+func (queue *Queue[T]) Empty() bool {
+	return queue.size == 0
+}
+
+// index returns the position in buffer of the i-th element of the queue.
+func (queue *Queue[T]) index(i int) int {
+	return (queue.head + i) & (len(queue.buffer) - 1)
+}
+
+// grow doubles the capacity of the buffer and moves the elements to the beginning of it.
+func (queue *Queue[T]) grow() {
+	n := len(queue.buffer) * 2
+	if n == 0 {
+		n = minCapacity
+	}
+	temp := make([]T, n)
+	if queue.size > 0 {
+		// the elements may wrap around the end of buffer.
+		k := copy(temp, queue.buffer[queue.head:])
+		copy(temp[k:], queue.buffer[:queue.head])
+	}
+	queue.buffer = temp
+	queue.head = 0
+}
+
+// Push adds value to the back of the queue with an amortized time complexity of O(1).
+func (queue *Queue[T]) Push(value T) {
+	if queue.size == len(queue.buffer) {
+		queue.grow()
+	}
+	queue.buffer[queue.index(queue.size)] = value
+	queue.size++
+}
+
+// Pop removes the front element of the queue and returns its value with a time complexity of O(1).
+// If the queue is empty, Pop will return the default value of T.
+func (queue *Queue[T]) Pop() (value T) {
+	if queue.size > 0 {
+		var zero T
+		temp := queue.buffer[queue.head]
+		queue.buffer[queue.head] = zero // avoid memory leaks
+		queue.head = queue.index(1)
+		queue.size--
+		return temp
+	}
+	return
+}
+
+// Front returns the value of the front element of the queue.
+// If the queue is empty, Front will return the default value of T.
+func (queue *Queue[T]) Front() (value T) {
+	if queue.size > 0 {
+		return queue.buffer[queue.head]
+	}
+	return
+}
+
+// Back returns the value of the back element of the queue.
+// If the queue is empty, Back will return the default value of T.
+func (queue *Queue[T]) Back() (value T) {
+	if queue.size > 0 {
+		return queue.buffer[queue.index(queue.size-1)]
+	}
+	return
+}
+
+// Clear removes all elements of the queue.
+// The capacity of the queue is kept so that its memory can be reused.
+func (queue *Queue[T]) Clear() {
+	clear(queue.buffer) // avoid memory leaks
+	queue.head = 0
+	queue.size = 0
+}
+
+// Values returns an iterator over the values of the queue from front to back without removing them.
+// The queue must not be modified during the iteration.
+func (queue *Queue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < queue.size; i++ {
+			if !yield(queue.buffer[queue.index(i)]) {
+				return
+			}
+		}
+	}
+}
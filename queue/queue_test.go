@@ -3,3 +3,120 @@
 // See the LICENSE file in the project root for more information.
 
 package queue
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func TestQueueBasicFunction(t *testing.T) {
+	queue := New[int]()
+	same(t, queue.Empty(), true)
+	same(t, queue.Front(), 0) // default value
+	same(t, queue.Back(), 0)  // default value
+	same(t, queue.Pop(), 0)   // default value
+
+	for i := 0; i < 10; i++ {
+		queue.Push(i)
+		same(t, queue.Front(), 0)
+		same(t, queue.Back(), i)
+		same(t, queue.Size(), i+1)
+	}
+
+	for i := 0; i < 10; i++ {
+		same(t, queue.Empty(), false)
+		same(t, queue.Front(), i)
+		same(t, queue.Pop(), i)
+	}
+	same(t, queue.Empty(), true)
+}
+
+func TestNewWithData(t *testing.T) {
+	queue := NewWithData(1, 2, 3, 4, 5)
+	same(t, queue.Size(), 5)
+	same(t, queue.Front(), 1)
+	same(t, queue.Back(), 5)
+	for i := 1; i <= 5; i++ {
+		same(t, queue.Pop(), i)
+	}
+}
+
+func TestWrapAround(t *testing.T) {
+	queue := New[int]()
+	for i := 0; i < minCapacity; i++ {
+		queue.Push(i)
+	}
+	capacity := queue.Capacity()
+
+	// head moves forward while the size stays the same, so the buffer must be reused.
+	for i := minCapacity; i < 100; i++ {
+		same(t, queue.Pop(), i-minCapacity)
+		queue.Push(i)
+		same(t, queue.Back(), i)
+		same(t, queue.Capacity(), capacity)
+	}
+
+	// grow while the elements wrap around the end of the buffer.
+	queue.Pop()
+	queue.Pop()
+	for i := 100; i < 110; i++ {
+		queue.Push(i)
+	}
+	for i := 100 - minCapacity + 2; i < 110; i++ {
+		same(t, queue.Pop(), i)
+	}
+	same(t, queue.Empty(), true)
+}
+
+func TestClear(t *testing.T) {
+	queue := NewWithData(1, 2, 3)
+	capacity := queue.Capacity()
+	queue.Clear()
+	same(t, queue.Empty(), true)
+	same(t, queue.Capacity(), capacity)
+	queue.Push(4)
+	same(t, queue.Front(), 4)
+	same(t, queue.Back(), 4)
+	queue.Clear()
+	queue.Clear()
+}
+
+func TestPushPopEfficiency(t *testing.T) {
+	const N = 10000000
+	queue := New[int]()
+
+	start := time.Now()
+	for i := 0; i < N; i++ {
+		queue.Push(i)
+	}
+	t.Log("Push", N, "val costs", time.Since(start))
+
+	start = time.Now()
+	for !queue.Empty() {
+		queue.Pop()
+	}
+	t.Log("Pop", N, "val costs", time.Since(start))
+}
+
+func TestValues(t *testing.T) {
+	queue := New[int]()
+	same(t, slices.Collect(queue.Values()), []int(nil))
+	for i := 0; i < 10; i++ {
+		queue.Push(i)
+	}
+	for i := 0; i < 5; i++ {
+		queue.Pop()
+		queue.Push(i + 10)
+	}
+	same(t, slices.Collect(queue.Values()), []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+	same(t, queue.Size(), 10)
+}
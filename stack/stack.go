@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package stack implements a LIFO container adapter like std::stack.
+//
+// A Stack[T] stores its elements in an underlying Container[T].
+// vector.Vector and deque.Deque satisfy Container[T] directly,
+// and a list.List can be used through FromList.
+package stack
+
+import (
+	"github.com/GitSteve1025/containers/list"
+	"github.com/GitSteve1025/containers/vector"
+)
+
+// Container is the underlying container of a Stack[T].
+// The top of the stack is the back of the container.
+type Container[T any] interface {
+	// Size returns the number of elements in the container.
+	Size() int
+	// Empty returns true if the container is empty.
+	Empty() bool
+	// Back returns the reference of the last element, or nil if the container is empty.
+	Back() *T
+	// PushBack adds val to the end of the container.
+	PushBack(val T)
+	// PopBack removes the last element and returns its value,
+	// or the default value of T if the container is empty.
+	PopBack() T
+}
+
+type Stack[T any] struct {
+	container Container[T]
+}
+
+// New creates a new empty Stack[T] backed by a vector.Vector[T].
+func New[T any]() *Stack[T] {
+	return NewWithContainer[T](vector.New[T]())
+}
+
+// NewWithContainer creates a Stack[T] backed by container.
+// The elements already in container are kept, its back is the top of the stack.
+// Container must not be nil, and should not be modified except through the stack.
+func NewWithContainer[T any](container Container[T]) *Stack[T] {
+	return &Stack[T]{
+		container: container,
+	}
+}
+
+// Size returns the number of elements in the stack.
+func (stack *Stack[T]) Size() int {
+	return stack.container.Size()
+}
+
+// Empty returns true if the stack is empty.
+func (stack *Stack[T]) Empty() bool {
+	return stack.container.Empty()
+}
+
+// Push adds value to the top of the stack.
+func (stack *Stack[T]) Push(value T) {
+	stack.container.PushBack(value)
+}
+
+// Top returns the value of the top element of the stack.
+// If the stack is empty, Top will return the default value of T.
+func (stack *Stack[T]) Top() (value T) {
+	if top := stack.container.Back(); top != nil {
+		return *top
+	}
+	return
+}
+
+// Pop removes the top element of the stack and returns its value.
+// If the stack is empty, Pop will return the default value of T.
+func (stack *Stack[T]) Pop() T {
+	return stack.container.PopBack()
+}
+
+// listContainer adapts list.List[T] to Container[T].
+type listContainer[T any] struct {
+	*list.List[T]
+}
+
+// FromList returns a Container[T] that stores its elements in list.
+// List must not be nil.
+func FromList[T any](list *list.List[T]) Container[T] {
+	return listContainer[T]{list}
+}
+
+func (container listContainer[T]) Back() *T {
+	if back := container.List.Back(); back != nil {
+		return &back.Value
+	}
+	return nil
+}
+
+func (container listContainer[T]) PushBack(val T) {
+	container.List.PushBack(val)
+}
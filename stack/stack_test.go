@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package stack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GitSteve1025/containers/deque"
+	"github.com/GitSteve1025/containers/list"
+	"github.com/GitSteve1025/containers/vector"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func testStack(t *testing.T, stack *Stack[int]) {
+	t.Helper()
+	same(t, stack.Empty(), true)
+	same(t, stack.Top(), 0) // default value
+	same(t, stack.Pop(), 0) // default value
+
+	for i := 0; i < 10; i++ {
+		stack.Push(i)
+		same(t, stack.Top(), i)
+		same(t, stack.Size(), i+1)
+	}
+	for i := 9; i >= 0; i-- {
+		same(t, stack.Empty(), false)
+		same(t, stack.Top(), i)
+		same(t, stack.Pop(), i)
+	}
+	same(t, stack.Empty(), true)
+}
+
+func TestStack(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		testStack(t, New[int]())
+	})
+	t.Run("Vector", func(t *testing.T) {
+		testStack(t, NewWithContainer[int](vector.New[int]()))
+	})
+	t.Run("Deque", func(t *testing.T) {
+		testStack(t, NewWithContainer[int](deque.New[int]()))
+	})
+	t.Run("List", func(t *testing.T) {
+		testStack(t, NewWithContainer(FromList(list.New[int]())))
+	})
+}
+
+func TestExistingElements(t *testing.T) {
+	vec := vector.NewWithData(1, 2, 3)
+	stack := NewWithContainer[int](vec)
+	same(t, stack.Top(), 3)
+	stack.Push(4)
+	same(t, *vec, vector.Vector[int]{1, 2, 3, 4})
+
+	lt := list.NewWithData(1, 2, 3)
+	stack = NewWithContainer(FromList(lt))
+	same(t, stack.Pop(), 3)
+	same(t, lt.Back().Value, 2)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package treemap
+
+import "iter"
+
+// Map is an ordered map with unique keys, like std::map.
+type Map[K any, V any] struct {
+	tree tree[K, V]
+}
+
+// New creates an empty Map[K, V] ordered by comparator.
+// Comparator must not be nil.
+func New[K any, V any](comparator func(left K, right K) bool) *Map[K, V] {
+	return &Map[K, V]{
+		tree: tree[K, V]{less: comparator},
+	}
+}
+
+// Size returns the number of elements in the map.
+func (m *Map[K, V]) Size() int {
+	return m.tree.size
+}
+
+// Empty returns true if the map is empty.
+func (m *Map[K, V]) Empty() bool {
+	return m.tree.size == 0
+}
+
+// Clear removes all elements of the map.
+func (m *Map[K, V]) Clear() {
+	m.tree.clear()
+}
+
+// Front returns the node with the smallest key.
+// Return nil when the map is empty.
+func (m *Map[K, V]) Front() *Node[K, V] {
+	return m.tree.first()
+}
+
+// Back returns the node with the largest key.
+// Return nil when the map is empty.
+func (m *Map[K, V]) Back() *Node[K, V] {
+	return m.tree.last()
+}
+
+// Insert inserts key with value and returns the new node and true.
+// If the map already contains key, the map is not modified, Insert returns the existing node and false.
+func (m *Map[K, V]) Insert(key K, value V) (*Node[K, V], bool) {
+	return m.tree.insert(key, value, true)
+}
+
+// InsertOrAssign inserts key with value and returns the new node and true.
+// If the map already contains key, its value is replaced, InsertOrAssign returns the existing node and false.
+func (m *Map[K, V]) InsertOrAssign(key K, value V) (*Node[K, V], bool) {
+	n, ok := m.tree.insert(key, value, true)
+	if !ok {
+		n.Value = value
+	}
+	return n, ok
+}
+
+// At returns a reference to the value mapped to key.
+// If the map does not contain key, At will return nil.
+func (m *Map[K, V]) At(key K) *V {
+	if n := m.tree.find(key); n != nil {
+		return &n.Value
+	}
+	return nil
+}
+
+// Find returns the node with key, or nil if the map does not contain key.
+func (m *Map[K, V]) Find(key K) *Node[K, V] {
+	return m.tree.find(key)
+}
+
+// Contains returns true if the map contains key.
+func (m *Map[K, V]) Contains(key K) bool {
+	return m.tree.find(key) != nil
+}
+
+// Erase removes key from the map and returns true.
+// If the map does not contain key, the map is not modified and Erase returns false.
+func (m *Map[K, V]) Erase(key K) bool {
+	if n := m.tree.find(key); n != nil {
+		m.tree.erase(n)
+		return true
+	}
+	return false
+}
+
+// EraseNode removes n from the map and returns the node that followed it, or nil.
+// If n is not a node of the map, the map is not modified and EraseNode returns nil.
+// The n must not be nil.
+func (m *Map[K, V]) EraseNode(n *Node[K, V]) *Node[K, V] {
+	if n.tree != &m.tree {
+		return nil
+	}
+	next := n.Next()
+	m.tree.erase(n)
+	return next
+}
+
+// LowerBound returns the first node whose key is not less than key, or nil.
+func (m *Map[K, V]) LowerBound(key K) *Node[K, V] {
+	return m.tree.lowerBound(key)
+}
+
+// UpperBound returns the first node whose key is greater than key, or nil.
+func (m *Map[K, V]) UpperBound(key K) *Node[K, V] {
+	return m.tree.upperBound(key)
+}
+
+// EqualRange returns the range [first, last) of nodes whose keys are equivalent to key.
+// It is equivalent to (LowerBound(key), UpperBound(key)), a nil last means the end of the map.
+func (m *Map[K, V]) EqualRange(key K) (first *Node[K, V], last *Node[K, V]) {
+	return m.tree.lowerBound(key), m.tree.upperBound(key)
+}
+
+// All returns an iterator over key-value pairs of the map in ascending key order.
+// The current element may be erased during the iteration.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.ascending())
+}
+
+// Backward returns an iterator over key-value pairs of the map in descending key order.
+// The current element may be erased during the iteration.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.descending())
+}
+
+// Range returns an iterator over key-value pairs of the map whose keys are in [lo, hi), in ascending key order.
+// The current element may be erased during the iteration.
+func (m *Map[K, V]) Range(lo K, hi K) iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.between(lo, hi))
+}
+
+// Keys returns an iterator over the keys of the map in ascending order.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return m.tree.keys(m.tree.ascending())
+}
+
+// Values returns an iterator over the values of the map in ascending key order.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return m.tree.values(m.tree.ascending())
+}
+
+// Select returns the node at index k in ascending key order, the k-th smallest starting from 0,
+// with a time complexity of O(log n).
+// If k is out of range, Select will return nil.
+func (m *Map[K, V]) Select(k int) *Node[K, V] {
+	return m.tree.selectAt(k)
+}
+
+// Rank returns the number of elements whose keys are less than key with a time complexity of O(log n).
+// It is the index of LowerBound(key) in ascending key order.
+func (m *Map[K, V]) Rank(key K) int {
+	return m.tree.rank(key)
+}
+
+// CountRange returns the number of elements whose keys are in [lo, hi) with a time complexity of O(log n).
+func (m *Map[K, V]) CountRange(lo K, hi K) int {
+	return m.tree.countRange(lo, hi)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package treemap
+
+import "iter"
+
+// MultiMap is an ordered map which allows equivalent keys, like std::multimap.
+// Elements with equivalent keys are kept in insertion order.
+type MultiMap[K any, V any] struct {
+	tree tree[K, V]
+}
+
+// NewMultiMap creates an empty MultiMap[K, V] ordered by comparator.
+// Comparator must not be nil.
+func NewMultiMap[K any, V any](comparator func(left K, right K) bool) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		tree: tree[K, V]{less: comparator},
+	}
+}
+
+// Size returns the number of elements in the map.
+func (m *MultiMap[K, V]) Size() int {
+	return m.tree.size
+}
+
+// Empty returns true if the map is empty.
+func (m *MultiMap[K, V]) Empty() bool {
+	return m.tree.size == 0
+}
+
+// Clear removes all elements of the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.tree.clear()
+}
+
+// Front returns the node with the smallest key.
+// Return nil when the map is empty.
+func (m *MultiMap[K, V]) Front() *Node[K, V] {
+	return m.tree.first()
+}
+
+// Back returns the node with the largest key.
+// Return nil when the map is empty.
+func (m *MultiMap[K, V]) Back() *Node[K, V] {
+	return m.tree.last()
+}
+
+// Insert inserts key with value after the elements with equivalent keys, and returns the new node.
+func (m *MultiMap[K, V]) Insert(key K, value V) *Node[K, V] {
+	n, _ := m.tree.insert(key, value, false)
+	return n
+}
+
+// Find returns the first node with key, or nil if the map does not contain key.
+func (m *MultiMap[K, V]) Find(key K) *Node[K, V] {
+	return m.tree.find(key)
+}
+
+// Contains returns true if the map contains key.
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	return m.tree.find(key) != nil
+}
+
+// Count returns the number of elements with key with a time complexity of O(log n).
+func (m *MultiMap[K, V]) Count(key K) int {
+	return m.tree.count(key)
+}
+
+// Erase removes all elements with key and returns the number of removed elements.
+func (m *MultiMap[K, V]) Erase(key K) int {
+	return m.tree.eraseRange(m.tree.lowerBound(key), m.tree.upperBound(key))
+}
+
+// EraseOne removes the first element with key and returns true.
+// If the map does not contain key, the map is not modified and EraseOne returns false.
+func (m *MultiMap[K, V]) EraseOne(key K) bool {
+	if n := m.tree.find(key); n != nil {
+		m.tree.erase(n)
+		return true
+	}
+	return false
+}
+
+// EraseNode removes n from the map and returns the node that followed it, or nil.
+// If n is not a node of the map, the map is not modified and EraseNode returns nil.
+// The n must not be nil.
+func (m *MultiMap[K, V]) EraseNode(n *Node[K, V]) *Node[K, V] {
+	if n.tree != &m.tree {
+		return nil
+	}
+	next := n.Next()
+	m.tree.erase(n)
+	return next
+}
+
+// LowerBound returns the first node whose key is not less than key, or nil.
+func (m *MultiMap[K, V]) LowerBound(key K) *Node[K, V] {
+	return m.tree.lowerBound(key)
+}
+
+// UpperBound returns the first node whose key is greater than key, or nil.
+func (m *MultiMap[K, V]) UpperBound(key K) *Node[K, V] {
+	return m.tree.upperBound(key)
+}
+
+// EqualRange returns the range [first, last) of nodes whose keys are equivalent to key.
+// It is equivalent to (LowerBound(key), UpperBound(key)), a nil last means the end of the map.
+func (m *MultiMap[K, V]) EqualRange(key K) (first *Node[K, V], last *Node[K, V]) {
+	return m.tree.lowerBound(key), m.tree.upperBound(key)
+}
+
+// AllWithKey returns an iterator over key-value pairs of the elements in EqualRange(key), in insertion order.
+// The current element may be erased during the iteration.
+func (m *MultiMap[K, V]) AllWithKey(key K) iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.equivalent(key))
+}
+
+// All returns an iterator over key-value pairs of the map in ascending key order.
+// The current element may be erased during the iteration.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.ascending())
+}
+
+// Backward returns an iterator over key-value pairs of the map in descending key order.
+// The current element may be erased during the iteration.
+func (m *MultiMap[K, V]) Backward() iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.descending())
+}
+
+// Range returns an iterator over key-value pairs of the map whose keys are in [lo, hi), in ascending key order.
+// The current element may be erased during the iteration.
+func (m *MultiMap[K, V]) Range(lo K, hi K) iter.Seq2[K, V] {
+	return m.tree.pairs(m.tree.between(lo, hi))
+}
+
+// Keys returns an iterator over the keys of the map in ascending order, equivalent keys are repeated.
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return m.tree.keys(m.tree.ascending())
+}
+
+// Values returns an iterator over the values of the map in ascending key order.
+func (m *MultiMap[K, V]) Values() iter.Seq[V] {
+	return m.tree.values(m.tree.ascending())
+}
+
+// Select returns the node at index k in ascending key order, the k-th smallest starting from 0,
+// with a time complexity of O(log n).
+// If k is out of range, Select will return nil.
+func (m *MultiMap[K, V]) Select(k int) *Node[K, V] {
+	return m.tree.selectAt(k)
+}
+
+// Rank returns the number of elements whose keys are less than key with a time complexity of O(log n).
+// It is the index of LowerBound(key) in ascending key order.
+func (m *MultiMap[K, V]) Rank(key K) int {
+	return m.tree.rank(key)
+}
+
+// CountRange returns the number of elements whose keys are in [lo, hi) with a time complexity of O(log n).
+func (m *MultiMap[K, V]) CountRange(lo K, hi K) int {
+	return m.tree.countRange(lo, hi)
+}
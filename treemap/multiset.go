@@ -0,0 +1,154 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package treemap
+
+import "iter"
+
+// MultiSet is an ordered set which allows equivalent keys, like std::multiset.
+// Equivalent keys are kept in insertion order.
+type MultiSet[K any] struct {
+	tree tree[K, struct{}]
+}
+
+// NewMultiSet creates an empty MultiSet[K] ordered by comparator.
+// Comparator must not be nil.
+func NewMultiSet[K any](comparator func(left K, right K) bool) *MultiSet[K] {
+	return &MultiSet[K]{
+		tree: tree[K, struct{}]{less: comparator},
+	}
+}
+
+// Size returns the number of keys in the set.
+func (s *MultiSet[K]) Size() int {
+	return s.tree.size
+}
+
+// Empty returns true if the set is empty.
+func (s *MultiSet[K]) Empty() bool {
+	return s.tree.size == 0
+}
+
+// Clear removes all keys of the set.
+func (s *MultiSet[K]) Clear() {
+	s.tree.clear()
+}
+
+// Front returns the node with the smallest key.
+// Return nil when the set is empty.
+func (s *MultiSet[K]) Front() *Node[K, struct{}] {
+	return s.tree.first()
+}
+
+// Back returns the node with the largest key.
+// Return nil when the set is empty.
+func (s *MultiSet[K]) Back() *Node[K, struct{}] {
+	return s.tree.last()
+}
+
+// Insert inserts key after the equivalent keys, and returns the new node.
+func (s *MultiSet[K]) Insert(key K) *Node[K, struct{}] {
+	n, _ := s.tree.insert(key, struct{}{}, false)
+	return n
+}
+
+// Find returns the first node with key, or nil if the set does not contain key.
+func (s *MultiSet[K]) Find(key K) *Node[K, struct{}] {
+	return s.tree.find(key)
+}
+
+// Contains returns true if the set contains key.
+func (s *MultiSet[K]) Contains(key K) bool {
+	return s.tree.find(key) != nil
+}
+
+// Count returns the number of occurrences of key with a time complexity of O(log n).
+func (s *MultiSet[K]) Count(key K) int {
+	return s.tree.count(key)
+}
+
+// Erase removes all occurrences of key and returns the number of removed keys.
+func (s *MultiSet[K]) Erase(key K) int {
+	return s.tree.eraseRange(s.tree.lowerBound(key), s.tree.upperBound(key))
+}
+
+// EraseOne removes the first occurrence of key and returns true.
+// If the set does not contain key, the set is not modified and EraseOne returns false.
+func (s *MultiSet[K]) EraseOne(key K) bool {
+	if n := s.tree.find(key); n != nil {
+		s.tree.erase(n)
+		return true
+	}
+	return false
+}
+
+// EraseNode removes n from the set and returns the node that followed it, or nil.
+// If n is not a node of the set, the set is not modified and EraseNode returns nil.
+// The n must not be nil.
+func (s *MultiSet[K]) EraseNode(n *Node[K, struct{}]) *Node[K, struct{}] {
+	if n.tree != &s.tree {
+		return nil
+	}
+	next := n.Next()
+	s.tree.erase(n)
+	return next
+}
+
+// LowerBound returns the first node whose key is not less than key, or nil.
+func (s *MultiSet[K]) LowerBound(key K) *Node[K, struct{}] {
+	return s.tree.lowerBound(key)
+}
+
+// UpperBound returns the first node whose key is greater than key, or nil.
+func (s *MultiSet[K]) UpperBound(key K) *Node[K, struct{}] {
+	return s.tree.upperBound(key)
+}
+
+// EqualRange returns the range [first, last) of nodes whose keys are equivalent to key.
+// It is equivalent to (LowerBound(key), UpperBound(key)), a nil last means the end of the set.
+func (s *MultiSet[K]) EqualRange(key K) (first *Node[K, struct{}], last *Node[K, struct{}]) {
+	return s.tree.lowerBound(key), s.tree.upperBound(key)
+}
+
+// AllWithKey returns an iterator over the keys in EqualRange(key), in insertion order.
+// The current key may be erased during the iteration.
+func (s *MultiSet[K]) AllWithKey(key K) iter.Seq[K] {
+	return s.tree.keys(s.tree.equivalent(key))
+}
+
+// All returns an iterator over the keys of the set in ascending order.
+// The current key may be erased during the iteration.
+func (s *MultiSet[K]) All() iter.Seq[K] {
+	return s.tree.keys(s.tree.ascending())
+}
+
+// Backward returns an iterator over the keys of the set in descending order.
+// The current key may be erased during the iteration.
+func (s *MultiSet[K]) Backward() iter.Seq[K] {
+	return s.tree.keys(s.tree.descending())
+}
+
+// Range returns an iterator over the keys of the set in [lo, hi), in ascending order.
+// The current key may be erased during the iteration.
+func (s *MultiSet[K]) Range(lo K, hi K) iter.Seq[K] {
+	return s.tree.keys(s.tree.between(lo, hi))
+}
+
+// Select returns the node at index k in ascending key order, the k-th smallest starting from 0,
+// with a time complexity of O(log n).
+// If k is out of range, Select will return nil.
+func (s *MultiSet[K]) Select(k int) *Node[K, struct{}] {
+	return s.tree.selectAt(k)
+}
+
+// Rank returns the number of keys less than key with a time complexity of O(log n).
+// It is the index of LowerBound(key) in ascending key order.
+func (s *MultiSet[K]) Rank(key K) int {
+	return s.tree.rank(key)
+}
+
+// CountRange returns the number of keys in [lo, hi) with a time complexity of O(log n).
+func (s *MultiSet[K]) CountRange(lo K, hi K) int {
+	return s.tree.countRange(lo, hi)
+}
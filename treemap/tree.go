@@ -0,0 +1,606 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package treemap implements ordered associative containers backed by a red-black tree,
+// similar to std::map, std::set, std::multimap and std::multiset in C++.
+//
+// Keys are ordered by a comparator less(left, right) which reports whether left is ordered before right,
+// two keys are equivalent when neither is less than the other.
+// Insert, Erase, Find, LowerBound and UpperBound have a time complexity of O(log n).
+// Every node also records the size of its subtree, so the order-statistic operations
+// Select, Rank and CountRange have a time complexity of O(log n) as well.
+//
+// To iterate over a map (where m is a *Map):
+//
+//	for n := m.Front(); n != nil; n = n.Next() {
+//		// do something with n.Key() and n.Value
+//	}
+//
+//	for k, v := range m.All() {
+//		// do something with k and v
+//	}
+package treemap
+
+import "iter"
+
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+// Node is an element of an ordered container.
+type Node[K any, V any] struct {
+	key K
+	// The value stored with the key.
+	Value V
+
+	left   *Node[K, V]
+	right  *Node[K, V]
+	parent *Node[K, V]
+	color  color
+	// number of nodes in the subtree rooted at this node.
+	size int
+	// The tree to which this node belongs.
+	tree *tree[K, V]
+}
+
+// Key returns the key of the node.
+// The key cannot be modified, since it determines the position of the node.
+func (n *Node[K, V]) Key() K {
+	return n.key
+}
+
+// Next returns the next node in key order or nil.
+func (n *Node[K, V]) Next() *Node[K, V] {
+	if n.tree == nil {
+		return nil
+	}
+	if n.right != nil {
+		return minimum(n.right)
+	}
+	for n.parent != nil && n == n.parent.right {
+		n = n.parent
+	}
+	return n.parent
+}
+
+// Prev returns the previous node in key order or nil.
+func (n *Node[K, V]) Prev() *Node[K, V] {
+	if n.tree == nil {
+		return nil
+	}
+	if n.left != nil {
+		return maximum(n.left)
+	}
+	for n.parent != nil && n == n.parent.left {
+		n = n.parent
+	}
+	return n.parent
+}
+
+// minimum returns the leftmost node of the subtree n.
+func minimum[K any, V any](n *Node[K, V]) *Node[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// maximum returns the rightmost node of the subtree n.
+func maximum[K any, V any](n *Node[K, V]) *Node[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// colorOf returns the color of n, nil leaves are black.
+func colorOf[K any, V any](n *Node[K, V]) color {
+	if n == nil {
+		return black
+	}
+	return n.color
+}
+
+// sizeOf returns the size of the subtree n, nil leaves are empty.
+func sizeOf[K any, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// red-black tree.
+type tree[K any, V any] struct {
+	root *Node[K, V]
+	// number of nodes.
+	size int
+	less func(left K, right K) bool
+}
+
+// first returns the node with the smallest key or nil.
+func (t *tree[K, V]) first() *Node[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	return minimum(t.root)
+}
+
+// last returns the node with the largest key or nil.
+func (t *tree[K, V]) last() *Node[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	return maximum(t.root)
+}
+
+// lowerBound returns the first node whose key is not less than key, or nil.
+func (t *tree[K, V]) lowerBound(key K) *Node[K, V] {
+	var result *Node[K, V]
+	for n := t.root; n != nil; {
+		if t.less(n.key, key) {
+			n = n.right
+		} else {
+			result = n
+			n = n.left
+		}
+	}
+	return result
+}
+
+// upperBound returns the first node whose key is greater than key, or nil.
+func (t *tree[K, V]) upperBound(key K) *Node[K, V] {
+	var result *Node[K, V]
+	for n := t.root; n != nil; {
+		if t.less(key, n.key) {
+			result = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return result
+}
+
+// find returns the first node whose key is equivalent to key, or nil.
+func (t *tree[K, V]) find(key K) *Node[K, V] {
+	if n := t.lowerBound(key); n != nil && !t.less(key, n.key) {
+		return n
+	}
+	return nil
+}
+
+// rank returns the number of nodes whose keys are less than key.
+func (t *tree[K, V]) rank(key K) int {
+	result := 0
+	for n := t.root; n != nil; {
+		if t.less(n.key, key) {
+			result += sizeOf(n.left) + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return result
+}
+
+// rankUpper returns the number of nodes whose keys are not greater than key.
+func (t *tree[K, V]) rankUpper(key K) int {
+	result := 0
+	for n := t.root; n != nil; {
+		if t.less(key, n.key) {
+			n = n.left
+		} else {
+			result += sizeOf(n.left) + 1
+			n = n.right
+		}
+	}
+	return result
+}
+
+// count returns the number of nodes whose keys are equivalent to key.
+func (t *tree[K, V]) count(key K) int {
+	return t.rankUpper(key) - t.rank(key)
+}
+
+// countRange returns the number of nodes whose keys are in [lo, hi).
+func (t *tree[K, V]) countRange(lo K, hi K) int {
+	if !t.less(lo, hi) {
+		return 0
+	}
+	return t.rank(hi) - t.rank(lo)
+}
+
+// selectAt returns the node at index k in key order, or nil if k is out of range.
+func (t *tree[K, V]) selectAt(k int) *Node[K, V] {
+	if k < 0 || k >= t.size {
+		return nil
+	}
+	n := t.root
+	for {
+		leftSize := sizeOf(n.left)
+		if k < leftSize {
+			n = n.left
+		} else if k > leftSize {
+			k -= leftSize + 1
+			n = n.right
+		} else {
+			return n
+		}
+	}
+}
+
+// rotateLeft rotates the subtree n to the left, n.right becomes the root of the subtree.
+func (t *tree[K, V]) rotateLeft(n *Node[K, V]) {
+	r := n.right
+	n.right = r.left
+	if r.left != nil {
+		r.left.parent = n
+	}
+	t.replaceChild(n, r)
+	r.left = n
+	n.parent = r
+	r.size = n.size
+	n.size = sizeOf(n.left) + sizeOf(n.right) + 1
+}
+
+// rotateRight rotates the subtree n to the right, n.left becomes the root of the subtree.
+func (t *tree[K, V]) rotateRight(n *Node[K, V]) {
+	l := n.left
+	n.left = l.right
+	if l.right != nil {
+		l.right.parent = n
+	}
+	t.replaceChild(n, l)
+	l.right = n
+	n.parent = l
+	l.size = n.size
+	n.size = sizeOf(n.left) + sizeOf(n.right) + 1
+}
+
+// replaceChild makes v take the place of u as a child of u.parent.
+// The children of u and v are not modified.
+func (t *tree[K, V]) replaceChild(u *Node[K, V], v *Node[K, V]) {
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// insert inserts a node with key and value, and returns it.
+// If unique is true and a node with an equivalent key exists, the tree is not modified, and insert returns that node and false.
+// If unique is false, the node is inserted after all nodes with equivalent keys.
+func (t *tree[K, V]) insert(key K, value V, unique bool) (*Node[K, V], bool) {
+	var parent *Node[K, V]
+	left := false
+	for n := t.root; n != nil; {
+		parent = n
+		if t.less(key, n.key) {
+			n = n.left
+			left = true
+		} else if !unique || t.less(n.key, key) {
+			n = n.right
+			left = false
+		} else {
+			return n, false
+		}
+	}
+
+	n := &Node[K, V]{
+		key:    key,
+		Value:  value,
+		parent: parent,
+		color:  red,
+		size:   1,
+		tree:   t,
+	}
+	if parent == nil {
+		t.root = n
+	} else if left {
+		parent.left = n
+	} else {
+		parent.right = n
+	}
+	for p := parent; p != nil; p = p.parent {
+		p.size++
+	}
+	t.size++
+	t.insertFixup(n)
+	return n, true
+}
+
+// insertFixup restores the red-black properties after n has been inserted.
+func (t *tree[K, V]) insertFixup(n *Node[K, V]) {
+	for colorOf(n.parent) == red {
+		parent := n.parent
+		grandparent := parent.parent
+		if parent == grandparent.left {
+			uncle := grandparent.right
+			if colorOf(uncle) == red {
+				parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				n = grandparent
+				continue
+			}
+			if n == parent.right {
+				n = parent
+				t.rotateLeft(n)
+				parent = n.parent
+			}
+			parent.color = black
+			grandparent.color = red
+			t.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+			if colorOf(uncle) == red {
+				parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				n = grandparent
+				continue
+			}
+			if n == parent.left {
+				n = parent
+				t.rotateRight(n)
+				parent = n.parent
+			}
+			parent.color = black
+			grandparent.color = red
+			t.rotateLeft(grandparent)
+		}
+	}
+	t.root.color = black
+}
+
+// erase removes n from the tree.
+// The other nodes are relinked rather than copied, so they stay valid.
+func (t *tree[K, V]) erase(n *Node[K, V]) {
+	// the node which is unlinked from its position is n itself, or its successor if n has two children.
+	removed := n
+	if n.left != nil && n.right != nil {
+		removed = minimum(n.right)
+	}
+	for p := removed.parent; p != nil; p = p.parent {
+		p.size--
+	}
+
+	// x takes the place of the removed node, its parent is tracked since x may be nil.
+	var x, xParent *Node[K, V]
+	removedColor := n.color
+	if n.left == nil {
+		x = n.right
+		xParent = n.parent
+		t.replaceChild(n, n.right)
+	} else if n.right == nil {
+		x = n.left
+		xParent = n.parent
+		t.replaceChild(n, n.left)
+	} else {
+		// the successor of n takes its place.
+		y := minimum(n.right)
+		removedColor = y.color
+		x = y.right
+		if y.parent == n {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.replaceChild(y, y.right)
+			y.right = n.right
+			y.right.parent = y
+		}
+		t.replaceChild(n, y)
+		y.left = n.left
+		y.left.parent = y
+		y.color = n.color
+		y.size = n.size
+	}
+	if removedColor == black {
+		t.eraseFixup(x, xParent)
+	}
+
+	n.left = nil   // avoid memory leaks
+	n.right = nil  // avoid memory leaks
+	n.parent = nil // avoid memory leaks
+	n.tree = nil
+	t.size--
+}
+
+// eraseRange removes the nodes in [first, last) and returns the number of removed nodes, a nil last means the end.
+func (t *tree[K, V]) eraseRange(first *Node[K, V], last *Node[K, V]) int {
+	result := 0
+	for n := first; n != last; result++ {
+		next := n.Next()
+		t.erase(n)
+		n = next
+	}
+	return result
+}
+
+// eraseFixup restores the red-black properties after a black node has been removed.
+// x carries an extra black, parent is the parent of x.
+func (t *tree[K, V]) eraseFixup(x *Node[K, V], parent *Node[K, V]) {
+	for x != t.root && colorOf(x) == black {
+		if x == parent.left {
+			sibling := parent.right
+			if colorOf(sibling) == red {
+				sibling.color = black
+				parent.color = red
+				t.rotateLeft(parent)
+				sibling = parent.right
+			}
+			if colorOf(sibling.left) == black && colorOf(sibling.right) == black {
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if colorOf(sibling.right) == black {
+				sibling.left.color = black
+				sibling.color = red
+				t.rotateRight(sibling)
+				sibling = parent.right
+			}
+			sibling.color = parent.color
+			parent.color = black
+			sibling.right.color = black
+			t.rotateLeft(parent)
+			x = t.root
+		} else {
+			sibling := parent.left
+			if colorOf(sibling) == red {
+				sibling.color = black
+				parent.color = red
+				t.rotateRight(parent)
+				sibling = parent.left
+			}
+			if colorOf(sibling.left) == black && colorOf(sibling.right) == black {
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if colorOf(sibling.left) == black {
+				sibling.right.color = black
+				sibling.color = red
+				t.rotateLeft(sibling)
+				sibling = parent.left
+			}
+			sibling.color = parent.color
+			parent.color = black
+			sibling.left.color = black
+			t.rotateRight(parent)
+			x = t.root
+		}
+	}
+	if x != nil {
+		x.color = black
+	}
+}
+
+// forward returns an iterator over the nodes in [first, last) in ascending key order, a nil last means the end.
+// The next node is fetched before the current one is yielded, so the current node may be erased.
+func (t *tree[K, V]) forward(first *Node[K, V], last *Node[K, V]) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		for n := first; n != last; {
+			next := n.Next()
+			if !yield(n) {
+				return
+			}
+			if next != nil && next.tree != t {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// backward returns an iterator over the nodes from last down to the first node in descending key order.
+// The previous node is fetched before the current one is yielded, so the current node may be erased.
+func (t *tree[K, V]) backward(last *Node[K, V]) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		for n := last; n != nil; {
+			prev := n.Prev()
+			if !yield(n) {
+				return
+			}
+			if prev != nil && prev.tree != t {
+				return
+			}
+			n = prev
+		}
+	}
+}
+
+// ascending returns an iterator over all nodes in ascending key order, the current node may be erased.
+func (t *tree[K, V]) ascending() iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		t.forward(t.first(), nil)(yield)
+	}
+}
+
+// descending returns an iterator over all nodes in descending key order, the current node may be erased.
+func (t *tree[K, V]) descending() iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		t.backward(t.last())(yield)
+	}
+}
+
+// between returns an iterator over the nodes whose keys are in [lo, hi) in ascending key order,
+// the current node may be erased. It is empty if lo is not less than hi.
+func (t *tree[K, V]) between(lo K, hi K) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		if t.less(lo, hi) {
+			t.forward(t.lowerBound(lo), t.lowerBound(hi))(yield)
+		}
+	}
+}
+
+// equivalent returns an iterator over the nodes whose keys are equivalent to key in insertion order,
+// the current node may be erased.
+func (t *tree[K, V]) equivalent(key K) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		t.forward(t.lowerBound(key), t.upperBound(key))(yield)
+	}
+}
+
+// pairs returns an iterator over the key-value pairs of nodes.
+func (t *tree[K, V]) pairs(nodes iter.Seq[*Node[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range nodes {
+			if !yield(n.key, n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// keys returns an iterator over the keys of nodes.
+func (t *tree[K, V]) keys(nodes iter.Seq[*Node[K, V]]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := range nodes {
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
+
+// values returns an iterator over the values of nodes.
+func (t *tree[K, V]) values(nodes iter.Seq[*Node[K, V]]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for n := range nodes {
+			if !yield(n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// clear removes all nodes from the tree.
+// The removed nodes are detached so that Next and Prev return nil.
+func (t *tree[K, V]) clear() {
+	var detach func(n *Node[K, V])
+	detach = func(n *Node[K, V]) {
+		if n != nil {
+			detach(n.left)
+			detach(n.right)
+			n.left = nil   // avoid memory leaks
+			n.right = nil  // avoid memory leaks
+			n.parent = nil // avoid memory leaks
+			n.tree = nil
+		}
+	}
+	detach(t.root)
+	t.root = nil
+	t.size = 0
+}
@@ -0,0 +1,473 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package treemap
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+func less(a int, b int) bool {
+	return a < b
+}
+
+// verify checks the red-black properties and the links of the tree.
+func verify[K any, V any](t *testing.T, tr *tree[K, V]) {
+	t.Helper()
+	if colorOf(tr.root) != black {
+		t.Fatal("root is not black")
+	}
+	var walk func(n *Node[K, V]) (blackHeight int, size int)
+	walk = func(n *Node[K, V]) (int, int) {
+		if n == nil {
+			return 1, 0
+		}
+		if n.tree != tr {
+			t.Fatal("node does not belong to the tree")
+		}
+		if n.left != nil && (n.left.parent != n || tr.less(n.key, n.left.key)) {
+			t.Fatal("left child is invalid")
+		}
+		if n.right != nil && (n.right.parent != n || tr.less(n.right.key, n.key)) {
+			t.Fatal("right child is invalid")
+		}
+		if n.color == red && (colorOf(n.left) == red || colorOf(n.right) == red) {
+			t.Fatal("red node has a red child")
+		}
+		leftHeight, leftSize := walk(n.left)
+		rightHeight, rightSize := walk(n.right)
+		if leftHeight != rightHeight {
+			t.Fatal("black height is unbalanced")
+		}
+		if n.size != leftSize+rightSize+1 {
+			t.Fatal("subtree size is invalid")
+		}
+		if n.color == black {
+			leftHeight++
+		}
+		return leftHeight, leftSize + rightSize + 1
+	}
+	_, size := walk(tr.root)
+	same(t, size, tr.size)
+}
+
+func TestMapBasicFunction(t *testing.T) {
+	m := New[int, string](less)
+	same(t, m.Empty(), true)
+	same(t, m.Front() == nil, true)
+	same(t, m.Back() == nil, true)
+
+	for _, k := range []int{5, 3, 8, 1, 4} {
+		n, ok := m.Insert(k, "v")
+		same(t, ok, true)
+		same(t, n.Key(), k)
+	}
+	n, ok := m.Insert(3, "x")
+	same(t, ok, false)
+	same(t, n.Value, "v") // not modified
+
+	n, ok = m.InsertOrAssign(3, "three")
+	same(t, ok, false)
+	same(t, n.Value, "three")
+	same(t, *m.At(3), "three")
+	same(t, m.At(7) == nil, true)
+
+	same(t, m.Size(), 5)
+	same(t, m.Front().Key(), 1)
+	same(t, m.Back().Key(), 8)
+	same(t, m.Contains(4), true)
+	same(t, m.Contains(6), false)
+	same(t, m.Find(6) == nil, true)
+
+	same(t, m.LowerBound(4).Key(), 4)
+	same(t, m.LowerBound(6).Key(), 8)
+	same(t, m.UpperBound(4).Key(), 5)
+	same(t, m.UpperBound(8) == nil, true)
+	first, last := m.EqualRange(5)
+	same(t, first.Key(), 5)
+	same(t, last.Key(), 8)
+	first, last = m.EqualRange(6)
+	same(t, first, last)
+
+	same(t, m.Erase(3), true)
+	same(t, m.Erase(3), false)
+	same(t, slices.Collect(m.Keys()), []int{1, 4, 5, 8})
+	verify(t, &m.tree)
+
+	m.Clear()
+	same(t, m.Empty(), true)
+	same(t, n.Next() == nil, true)
+}
+
+func TestMapIterator(t *testing.T) {
+	m := New[int, int](less)
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*i)
+	}
+
+	var keys []int
+	for n := m.Front(); n != nil; n = n.Next() {
+		keys = append(keys, n.Key())
+	}
+	same(t, keys, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	keys = keys[:0]
+	for k, v := range m.Backward() {
+		same(t, v, k*k)
+		keys = append(keys, k)
+	}
+	same(t, keys, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0})
+
+	keys = keys[:0]
+	for k := range m.Range(3, 6) {
+		keys = append(keys, k)
+	}
+	same(t, keys, []int{3, 4, 5})
+	for range m.Range(6, 3) {
+		t.Fatal("Range with lo > hi must be empty")
+	}
+
+	// erase during the iteration.
+	for k := range m.All() {
+		if k%2 == 1 {
+			m.Erase(k)
+		}
+	}
+	same(t, slices.Collect(m.Values()), []int{0, 4, 16, 36, 64})
+
+	// erase by node.
+	for n := m.Front(); n != nil; {
+		n = m.EraseNode(n)
+	}
+	same(t, m.Empty(), true)
+
+	other := New[int, int](less)
+	n, _ := other.Insert(1, 1)
+	same(t, m.EraseNode(n) == nil, true)
+	same(t, other.Size(), 1)
+}
+
+func TestMapRandom(t *testing.T) {
+	const N = 100000
+	m := New[int, int](less)
+	expect := make(map[int]int)
+	for i := 0; i < N; i++ {
+		k := rand.Intn(N / 10)
+		if rand.Intn(3) == 0 {
+			_, ok := expect[k]
+			same(t, m.Erase(k), ok)
+			delete(expect, k)
+		} else {
+			m.InsertOrAssign(k, i)
+			expect[k] = i
+		}
+		if i%10000 == 0 {
+			verify(t, &m.tree)
+		}
+	}
+	verify(t, &m.tree)
+	same(t, m.Size(), len(expect))
+
+	var keys []int
+	for k := range expect {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	same(t, slices.Collect(m.Keys()), keys)
+	for k, v := range m.All() {
+		same(t, v, expect[k])
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet(func(a string, b string) bool {
+		return a < b
+	})
+	for _, k := range []string{"b", "d", "a", "c"} {
+		same(t, s.Insert(k), true)
+	}
+	same(t, s.Insert("a"), false)
+	same(t, s.Size(), 4)
+	same(t, s.Contains("c"), true)
+	same(t, s.Find("e") == nil, true)
+	same(t, s.Front().Key(), "a")
+	same(t, s.Back().Key(), "d")
+	same(t, s.LowerBound("bb").Key(), "c")
+	same(t, s.UpperBound("c").Key(), "d")
+	first, last := s.EqualRange("b")
+	same(t, first.Key(), "b")
+	same(t, last.Key(), "c")
+
+	same(t, slices.Collect(s.All()), []string{"a", "b", "c", "d"})
+	same(t, slices.Collect(s.Backward()), []string{"d", "c", "b", "a"})
+	same(t, slices.Collect(s.Range("b", "d")), []string{"b", "c"})
+
+	same(t, s.Erase("b"), true)
+	same(t, s.Erase("b"), false)
+	same(t, s.EraseNode(s.Front()).Key(), "c")
+	same(t, slices.Collect(s.All()), []string{"c", "d"})
+	verify(t, &s.tree)
+
+	s.Clear()
+	same(t, s.Empty(), true)
+}
+
+func TestEfficiency(t *testing.T) {
+	const N = 1000000
+	m := New[int, int](less)
+
+	start := time.Now()
+	for i := 0; i < N; i++ {
+		m.Insert(rand.Int(), i)
+	}
+	t.Log("Insert", N, "val costs", time.Since(start))
+
+	start = time.Now()
+	for m.Size() > 0 {
+		m.EraseNode(m.Front())
+	}
+	t.Log("Erase", N, "val costs", time.Since(start))
+}
+
+func TestMultiMap(t *testing.T) {
+	type record struct {
+		timestamp int
+		id        int
+	}
+
+	m := NewMultiMap[int, string](less)
+	m.Insert(2, "b1")
+	m.Insert(1, "a1")
+	m.Insert(2, "b2")
+	m.Insert(3, "c1")
+	m.Insert(2, "b3")
+	same(t, m.Size(), 5)
+	same(t, m.Count(2), 3)
+	same(t, m.Count(4), 0)
+	same(t, m.Contains(3), true)
+	same(t, m.Find(2).Value, "b1")
+	same(t, m.Front().Value, "a1")
+	same(t, m.Back().Value, "c1")
+
+	// equivalent keys are kept in insertion order.
+	var values []string
+	for k, v := range m.AllWithKey(2) {
+		same(t, k, 2)
+		values = append(values, v)
+	}
+	same(t, values, []string{"b1", "b2", "b3"})
+	first, last := m.EqualRange(2)
+	same(t, first.Value, "b1")
+	same(t, last.Value, "c1")
+	same(t, m.LowerBound(2).Value, "b1")
+	same(t, m.UpperBound(2).Value, "c1")
+	same(t, slices.Collect(m.Keys()), []int{1, 2, 2, 2, 3})
+
+	same(t, m.EraseOne(2), true)
+	same(t, slices.Collect(m.Values()), []string{"a1", "b2", "b3", "c1"})
+	same(t, m.Erase(2), 2)
+	same(t, m.Erase(2), 0)
+	same(t, m.EraseOne(2), false)
+	same(t, slices.Collect(m.Values()), []string{"a1", "c1"})
+	verify(t, &m.tree)
+
+	// iteration in both directions and over a range of keys.
+	m.Insert(2, "b4")
+	m.Insert(0, "z1")
+	m.Insert(2, "b5")
+	var pairs []string
+	for k, v := range m.All() {
+		pairs = append(pairs, strconv.Itoa(k)+v)
+	}
+	same(t, pairs, []string{"0z1", "1a1", "2b4", "2b5", "3c1"})
+	pairs = nil
+	for k, v := range m.Backward() {
+		pairs = append(pairs, strconv.Itoa(k)+v)
+	}
+	same(t, pairs, []string{"3c1", "2b5", "2b4", "1a1", "0z1"})
+	pairs = nil
+	for k, v := range m.Range(1, 3) {
+		pairs = append(pairs, strconv.Itoa(k)+v)
+	}
+	same(t, pairs, []string{"1a1", "2b4", "2b5"})
+	for range m.Range(3, 1) {
+		t.Fatal("Range with lo not less than hi must be empty")
+	}
+	for k, v := range m.All() {
+		if k == 1 {
+			break
+		}
+		same(t, v, "z1")
+	}
+
+	// the current element may be erased during the iteration.
+	for k := range m.All() {
+		if k == 2 {
+			m.EraseOne(2)
+		}
+	}
+	same(t, slices.Collect(m.Values()), []string{"z1", "a1", "c1"})
+
+	// EraseNode returns the next node, and ignores the nodes of other maps.
+	other := NewMultiMap[int, string](less)
+	foreign := other.Insert(1, "x")
+	same(t, m.EraseNode(foreign) == nil, true)
+	same(t, other.Size(), 1)
+	same(t, m.EraseNode(m.Find(1)).Value, "c1")
+	same(t, m.EraseNode(m.Back()) == nil, true)
+	same(t, slices.Collect(m.Values()), []string{"z1"})
+
+	same(t, m.Empty(), false)
+	m.Clear()
+	same(t, m.Empty(), true)
+	same(t, m.Size(), 0)
+	same(t, m.Front() == nil, true)
+	same(t, len(slices.Collect(m.Keys())), 0)
+	m.Insert(5, "e1")
+	same(t, slices.Collect(m.Values()), []string{"e1"})
+	verify(t, &m.tree)
+
+	// group records by timestamp with many duplicates.
+	const N = 10000
+	records := NewMultiMap[int, record](less)
+	groups := make(map[int][]record)
+	for i := 0; i < N; i++ {
+		r := record{timestamp: rand.Intn(100), id: i}
+		records.Insert(r.timestamp, r)
+		groups[r.timestamp] = append(groups[r.timestamp], r)
+	}
+	// EraseOne removes the oldest record of the timestamp.
+	for i := 0; i < N/2; i++ {
+		timestamp := rand.Intn(100)
+		same(t, records.EraseOne(timestamp), len(groups[timestamp]) > 0)
+		if len(groups[timestamp]) > 0 {
+			groups[timestamp] = groups[timestamp][1:]
+		}
+	}
+	verify(t, &records.tree)
+
+	var expect []record
+	for timestamp := 0; timestamp < 100; timestamp++ {
+		same(t, records.Count(timestamp), len(groups[timestamp]))
+		expect = append(expect, groups[timestamp]...)
+	}
+	same(t, slices.Collect(records.Values()), expect)
+}
+
+func TestMultiSet(t *testing.T) {
+	s := NewMultiSet[int](less)
+	for _, k := range []int{3, 1, 3, 2, 3, 1} {
+		s.Insert(k)
+	}
+	same(t, s.Size(), 6)
+	same(t, s.Count(3), 3)
+	same(t, s.Count(1), 2)
+	same(t, s.Contains(4), false)
+	same(t, s.Find(4) == nil, true)
+	same(t, slices.Collect(s.All()), []int{1, 1, 2, 3, 3, 3})
+	same(t, slices.Collect(s.Backward()), []int{3, 3, 3, 2, 1, 1})
+	same(t, slices.Collect(s.Range(1, 3)), []int{1, 1, 2})
+	same(t, slices.Collect(s.AllWithKey(3)), []int{3, 3, 3})
+	first, last := s.EqualRange(1)
+	same(t, first, s.Front())
+	same(t, last.Key(), 2)
+	same(t, s.LowerBound(2).Key(), 2)
+	same(t, s.UpperBound(2).Key(), 3)
+
+	same(t, s.EraseOne(3), true)
+	same(t, s.Count(3), 2)
+	same(t, s.Erase(1), 2)
+	same(t, s.EraseNode(s.Front()).Key(), 3)
+	same(t, slices.Collect(s.All()), []int{3, 3})
+	verify(t, &s.tree)
+
+	s.Clear()
+	same(t, s.Empty(), true)
+	same(t, s.Back() == nil, true)
+}
+
+func TestOrderStatistic(t *testing.T) {
+	const N = 10000
+	s := NewMultiSet[int](less)
+	var expect []int
+	for i := 0; i < N; i++ {
+		k := rand.Intn(N)
+		s.Insert(k)
+		expect = append(expect, k)
+	}
+	for i := 0; i < N/2; i++ {
+		s.EraseOne(expect[i])
+	}
+	expect = expect[N/2:]
+	sort.Ints(expect)
+	verify(t, &s.tree)
+
+	same(t, s.Select(-1) == nil, true)
+	same(t, s.Select(len(expect)) == nil, true)
+	for i := 0; i < 1000; i++ {
+		k := rand.Intn(len(expect))
+		same(t, s.Select(k).Key(), expect[k])
+
+		key := rand.Intn(N)
+		rank := sort.SearchInts(expect, key)
+		same(t, s.Rank(key), rank)
+		same(t, s.Count(key), sort.SearchInts(expect, key+1)-rank)
+
+		lo, hi := rand.Intn(N), rand.Intn(N)
+		count := 0
+		if lo < hi {
+			count = sort.SearchInts(expect, hi) - sort.SearchInts(expect, lo)
+		}
+		same(t, s.CountRange(lo, hi), count)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	// scores in descending order.
+	board := New[int, string](func(a int, b int) bool {
+		return a > b
+	})
+	board.Insert(300, "carol")
+	board.Insert(100, "alice")
+	board.Insert(200, "bob")
+	board.Insert(50, "dave")
+
+	same(t, board.Select(0).Value, "carol")
+	same(t, board.Select(2).Value, "alice")
+	same(t, board.Rank(200), 1)
+	same(t, board.Rank(150), 2)
+	same(t, board.CountRange(250, 60), 2)
+	board.Erase(300)
+	same(t, board.Select(0).Value, "bob")
+	same(t, board.Rank(50), 2)
+
+	set := NewSet[int](less)
+	for i := 0; i < 100; i += 10 {
+		set.Insert(i)
+	}
+	same(t, set.Select(5).Key(), 50)
+	same(t, set.Rank(55), 6)
+	same(t, set.CountRange(15, 55), 4)
+
+	multimap := NewMultiMap[int, int](less)
+	for i := 0; i < 10; i++ {
+		multimap.Insert(i/3, i)
+	}
+	same(t, multimap.Select(4).Value, 4)
+	same(t, multimap.Rank(2), 6)
+	same(t, multimap.CountRange(1, 3), 6)
+}
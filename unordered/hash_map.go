@@ -0,0 +1,172 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package unordered
+
+import "iter"
+
+// HashMap is a hash map with unique keys, like std::unordered_map.
+type HashMap[K any, V any] struct {
+	table table[K, V]
+}
+
+// New creates an empty HashMap[K, V].
+// Keys which are equal must have the same hash.
+// Hash and equal must not be nil.
+func New[K any, V any](hash func(key K) uint64, equal func(left K, right K) bool) *HashMap[K, V] {
+	return &HashMap[K, V]{
+		table: newTable[K, V](hash, equal),
+	}
+}
+
+// Size returns the number of elements in the map.
+func (m *HashMap[K, V]) Size() int {
+	return m.table.size
+}
+
+// Empty returns true if the map is empty.
+func (m *HashMap[K, V]) Empty() bool {
+	return m.table.size == 0
+}
+
+// Clear removes all elements of the map, the buckets are kept.
+func (m *HashMap[K, V]) Clear() {
+	m.table.clear()
+}
+
+// Insert inserts key with value and returns true.
+// If the map already contains key, the map is not modified and Insert returns false.
+func (m *HashMap[K, V]) Insert(key K, value V) bool {
+	_, ok := m.table.insert(key, value)
+	return ok
+}
+
+// InsertOrAssign inserts key with value and returns true.
+// If the map already contains key, its value is replaced and InsertOrAssign returns false.
+func (m *HashMap[K, V]) InsertOrAssign(key K, value V) bool {
+	i, ok := m.table.insert(key, value)
+	if !ok {
+		m.table.slots[i].value = value
+	}
+	return ok
+}
+
+// At returns a pointer to the value mapped to key.
+// Return nil if the map does not contain key.
+// The pointer is invalidated by the next insertion or removal.
+func (m *HashMap[K, V]) At(key K) *V {
+	if i := m.table.find(key); i != -1 {
+		return &m.table.slots[i].value
+	}
+	return nil
+}
+
+// Get returns the value mapped to key and true.
+// If the map does not contain key, Get will return the default value of V and false.
+func (m *HashMap[K, V]) Get(key K) (value V, ok bool) {
+	if i := m.table.find(key); i != -1 {
+		return m.table.slots[i].value, true
+	}
+	return
+}
+
+// Contains returns true if the map contains key.
+func (m *HashMap[K, V]) Contains(key K) bool {
+	return m.table.find(key) != -1
+}
+
+// Erase removes key from the map and returns true.
+// If the map does not contain key, the map is not modified and Erase returns false.
+func (m *HashMap[K, V]) Erase(key K) bool {
+	if i := m.table.find(key); i != -1 {
+		m.table.erase(i)
+		return true
+	}
+	return false
+}
+
+// All returns an iterator over key-value pairs of the map in an unspecified order.
+// The map must not be modified during the iteration.
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range m.table.slots {
+			s := &m.table.slots[i]
+			if s.distance != 0 && !yield(s.key, s.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map in an unspecified order.
+// The map must not be modified during the iteration.
+func (m *HashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in an unspecified order.
+// The map must not be modified during the iteration.
+func (m *HashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// BucketCount returns the number of buckets.
+func (m *HashMap[K, V]) BucketCount() int {
+	return len(m.table.slots)
+}
+
+// Bucket returns the index of the bucket of key, whether or not the map contains key.
+// Return -1 if the map has no bucket.
+func (m *HashMap[K, V]) Bucket(key K) int {
+	if len(m.table.slots) == 0 {
+		return -1
+	}
+	return m.table.bucket(m.table.hash(key))
+}
+
+// BucketSize returns the number of elements in bucket n.
+// Return 0 if n is out of range.
+func (m *HashMap[K, V]) BucketSize(n int) int {
+	return m.table.bucketSize(n)
+}
+
+// LoadFactor returns the average number of elements per bucket.
+func (m *HashMap[K, V]) LoadFactor() float64 {
+	return m.table.loadFactor()
+}
+
+// MaxLoadFactor returns the load factor above which the number of buckets grows.
+func (m *HashMap[K, V]) MaxLoadFactor() float64 {
+	return m.table.maxLoadFactor
+}
+
+// SetMaxLoadFactor sets the load factor above which the number of buckets grows, and rehashes the map if needed.
+// SetMaxLoadFactor panics if factor is not in (0, 1).
+func (m *HashMap[K, V]) SetMaxLoadFactor(factor float64) {
+	m.table.setMaxLoadFactor(factor)
+}
+
+// Rehash sets the number of buckets to the smallest power of two which is not less than count
+// and can hold the elements of the map within the maximum load factor.
+// Rehash may shrink the map, Rehash(0) fits the buckets to the elements.
+func (m *HashMap[K, V]) Rehash(count int) {
+	m.table.rehash(count)
+}
+
+// Reserve makes room for at least count elements, so that they can be inserted without a rehash.
+func (m *HashMap[K, V]) Reserve(count int) {
+	m.table.reserve(count)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package unordered
+
+import "iter"
+
+// HashSet is a hash set with unique keys, like std::unordered_set.
+type HashSet[K any] struct {
+	table table[K, struct{}]
+}
+
+// NewSet creates an empty HashSet[K].
+// Keys which are equal must have the same hash.
+// Hash and equal must not be nil.
+func NewSet[K any](hash func(key K) uint64, equal func(left K, right K) bool) *HashSet[K] {
+	return &HashSet[K]{
+		table: newTable[K, struct{}](hash, equal),
+	}
+}
+
+// Size returns the number of keys in the set.
+func (s *HashSet[K]) Size() int {
+	return s.table.size
+}
+
+// Empty returns true if the set is empty.
+func (s *HashSet[K]) Empty() bool {
+	return s.table.size == 0
+}
+
+// Clear removes all keys of the set, the buckets are kept.
+func (s *HashSet[K]) Clear() {
+	s.table.clear()
+}
+
+// Insert inserts key into the set and returns true.
+// If the set already contains key, the set is not modified and Insert returns false.
+func (s *HashSet[K]) Insert(key K) bool {
+	_, ok := s.table.insert(key, struct{}{})
+	return ok
+}
+
+// Contains returns true if the set contains key.
+func (s *HashSet[K]) Contains(key K) bool {
+	return s.table.find(key) != -1
+}
+
+// Erase removes key from the set and returns true.
+// If the set does not contain key, the set is not modified and Erase returns false.
+func (s *HashSet[K]) Erase(key K) bool {
+	if i := s.table.find(key); i != -1 {
+		s.table.erase(i)
+		return true
+	}
+	return false
+}
+
+// All returns an iterator over the keys of the set in an unspecified order.
+// The set must not be modified during the iteration.
+func (s *HashSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for i := range s.table.slots {
+			if s.table.slots[i].distance != 0 && !yield(s.table.slots[i].key) {
+				return
+			}
+		}
+	}
+}
+
+// BucketCount returns the number of buckets.
+func (s *HashSet[K]) BucketCount() int {
+	return len(s.table.slots)
+}
+
+// Bucket returns the index of the bucket of key, whether or not the set contains key.
+// Return -1 if the set has no bucket.
+func (s *HashSet[K]) Bucket(key K) int {
+	if len(s.table.slots) == 0 {
+		return -1
+	}
+	return s.table.bucket(s.table.hash(key))
+}
+
+// BucketSize returns the number of keys in bucket n.
+// Return 0 if n is out of range.
+func (s *HashSet[K]) BucketSize(n int) int {
+	return s.table.bucketSize(n)
+}
+
+// LoadFactor returns the average number of keys per bucket.
+func (s *HashSet[K]) LoadFactor() float64 {
+	return s.table.loadFactor()
+}
+
+// MaxLoadFactor returns the load factor above which the number of buckets grows.
+func (s *HashSet[K]) MaxLoadFactor() float64 {
+	return s.table.maxLoadFactor
+}
+
+// SetMaxLoadFactor sets the load factor above which the number of buckets grows, and rehashes the set if needed.
+// SetMaxLoadFactor panics if factor is not in (0, 1).
+func (s *HashSet[K]) SetMaxLoadFactor(factor float64) {
+	s.table.setMaxLoadFactor(factor)
+}
+
+// Rehash sets the number of buckets to the smallest power of two which is not less than count
+// and can hold the keys of the set within the maximum load factor.
+// Rehash may shrink the set, Rehash(0) fits the buckets to the keys.
+func (s *HashSet[K]) Rehash(count int) {
+	s.table.rehash(count)
+}
+
+// Reserve makes room for at least count keys, so that they can be inserted without a rehash.
+func (s *HashSet[K]) Reserve(count int) {
+	s.table.reserve(count)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+// Package unordered implements hash maps and sets with user supplied hash and equality functions,
+// like std::unordered_map and std::unordered_set.
+//
+// Unlike the built-in map, the keys do not have to be comparable: slices, structs holding slices
+// and types with a custom equality can be used as keys.
+// The hash function must return the same value for keys which are equal.
+//
+// The tables use open addressing with Robin Hood hashing and backward shift deletion:
+// the elements are stored inline in a single slice, and lookups probe a few consecutive slots.
+// A bucket is a slot of the table, and the bucket of a key is the slot where its probe sequence starts.
+//
+// For example, a map keyed on byte slices:
+//
+//	seed := maphash.MakeSeed()
+//	m := unordered.New[[]byte, int](func(key []byte) uint64 {
+//		return maphash.Bytes(seed, key)
+//	}, bytes.Equal)
+package unordered
+
+import "math/bits"
+
+const (
+	// minBuckets is the minimum number of buckets of a table which is not empty.
+	minBuckets = 8
+	// defaultMaxLoadFactor is the default maximum load factor.
+	defaultMaxLoadFactor = 0.8
+)
+
+// slot is a bucket of a table.
+type slot[K any, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	// distance is one more than the distance between the slot and the bucket of the key,
+	// 0 means the slot is empty.
+	distance uint32
+}
+
+// table is a Robin Hood hash table.
+// Along a probe sequence, the elements are sorted by their buckets.
+type table[K any, V any] struct {
+	slots         []slot[K, V]
+	size          int
+	maxLoadFactor float64
+	hash          func(key K) uint64
+	equal         func(left K, right K) bool
+}
+
+// newTable creates an empty table.
+func newTable[K any, V any](hash func(key K) uint64, equal func(left K, right K) bool) table[K, V] {
+	return table[K, V]{
+		maxLoadFactor: defaultMaxLoadFactor,
+		hash:          hash,
+		equal:         equal,
+	}
+}
+
+// mask returns the mask of the bucket indexes, the number of buckets is a power of two.
+func (t *table[K, V]) mask() int {
+	return len(t.slots) - 1
+}
+
+// bucket returns the bucket of a hash.
+// The hash is mixed first, so that hash functions with weak low bits still spread well.
+func (t *table[K, V]) bucket(hash uint64) int {
+	hash *= 0x9e3779b97f4a7c15
+	hash ^= hash >> 32
+	return int(hash) & t.mask()
+}
+
+// find returns the index of the slot holding key, or -1.
+func (t *table[K, V]) find(key K) int {
+	if t.size == 0 {
+		return -1 // do not hash
+	}
+	return t.findHash(key, t.hash(key))
+}
+
+// findHash returns the index of the slot holding key whose hash is given, or -1.
+func (t *table[K, V]) findHash(key K, hash uint64) int {
+	if t.size == 0 {
+		return -1
+	}
+	i := t.bucket(hash)
+	for distance := uint32(1); ; distance++ {
+		s := &t.slots[i]
+		// an element of the probe sequence would have been placed before s.
+		if s.distance < distance {
+			return -1
+		}
+		if s.hash == hash && t.equal(s.key, key) {
+			return i
+		}
+		i = (i + 1) & t.mask()
+	}
+}
+
+// place puts s into the table, which must have a free slot and must not contain the key of s.
+// It returns the index of the slot holding s.
+func (t *table[K, V]) place(s slot[K, V]) int {
+	i := t.bucket(s.hash)
+	s.distance = 1
+	index := -1
+	for {
+		current := &t.slots[i]
+		if current.distance == 0 {
+			*current = s
+			if index == -1 {
+				index = i
+			}
+			return index
+		}
+		// take the slot from the richer element, which continues probing.
+		if current.distance < s.distance {
+			*current, s = s, *current
+			if index == -1 {
+				index = i
+			}
+		}
+		i = (i + 1) & t.mask()
+		s.distance++
+	}
+}
+
+// insert inserts key with value and returns the index of its slot and true.
+// If the table already contains key, insert returns the index of its slot and false.
+func (t *table[K, V]) insert(key K, value V) (int, bool) {
+	hash := t.hash(key)
+	if i := t.findHash(key, hash); i != -1 {
+		return i, false
+	}
+	if float64(t.size+1) > float64(len(t.slots))*t.maxLoadFactor {
+		t.rehash(max(len(t.slots)*2, minBuckets))
+	}
+	t.size++
+	return t.place(slot[K, V]{key: key, value: value, hash: hash}), true
+}
+
+// erase removes the element at index i.
+// The following elements of the probe sequence are shifted back, so no tombstone is left.
+func (t *table[K, V]) erase(i int) {
+	j := (i + 1) & t.mask()
+	for t.slots[j].distance > 1 {
+		t.slots[i] = t.slots[j]
+		t.slots[i].distance--
+		i = j
+		j = (j + 1) & t.mask()
+	}
+	t.slots[i] = slot[K, V]{} // avoid memory leaks
+	t.size--
+}
+
+// rehash sets the number of buckets to the smallest power of two which is not less than count
+// and can hold the elements within the maximum load factor.
+func (t *table[K, V]) rehash(count int) {
+	count = max(count, int(float64(t.size)/t.maxLoadFactor)+1)
+	if t.size == 0 && count <= 1 {
+		t.slots = nil
+		return
+	}
+	count = max(count, minBuckets)
+	count = 1 << bits.Len(uint(count-1))
+	if count == len(t.slots) {
+		return
+	}
+	slots := t.slots
+	t.slots = make([]slot[K, V], count)
+	for _, s := range slots {
+		if s.distance != 0 {
+			t.place(s)
+		}
+	}
+}
+
+// reserve makes room for count elements without exceeding the maximum load factor.
+func (t *table[K, V]) reserve(count int) {
+	if need := int(float64(count) / t.maxLoadFactor); need >= len(t.slots) {
+		t.rehash(need + 1)
+	}
+}
+
+// setMaxLoadFactor sets the maximum load factor and rehashes the table if it is exceeded.
+func (t *table[K, V]) setMaxLoadFactor(factor float64) {
+	if !(factor > 0 && factor < 1) {
+		panic("unordered: max load factor must be in (0, 1)")
+	}
+	t.maxLoadFactor = factor
+	if float64(t.size) > float64(len(t.slots))*factor {
+		t.rehash(len(t.slots))
+	}
+}
+
+// loadFactor returns the average number of elements per bucket.
+func (t *table[K, V]) loadFactor() float64 {
+	if len(t.slots) == 0 {
+		return 0
+	}
+	return float64(t.size) / float64(len(t.slots))
+}
+
+// bucketSize returns the number of elements in bucket n.
+func (t *table[K, V]) bucketSize(n int) int {
+	if n < 0 || n >= len(t.slots) {
+		return 0
+	}
+	// the elements of bucket n come after the elements of the previous buckets,
+	// and before the elements of the following buckets.
+	count := 0
+	for i, k := n, uint32(1); t.slots[i].distance >= k; i, k = (i+1)&t.mask(), k+1 {
+		if t.slots[i].distance == k {
+			count++
+		}
+	}
+	return count
+}
+
+// clear removes all elements of the table, the buckets are kept.
+func (t *table[K, V]) clear() {
+	clear(t.slots)
+	t.size = 0
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package unordered
+
+import (
+	"bytes"
+	"fmt"
+	"hash/maphash"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func same(t *testing.T, left any, right any) {
+	t.Helper()
+	if !reflect.DeepEqual(left, right) {
+		t.Fatal(left, "is not equal to", right)
+	}
+}
+
+var seed = maphash.MakeSeed()
+
+func hashBytes(key []byte) uint64 {
+	return maphash.Bytes(seed, key)
+}
+
+func hashInt(key int) uint64 {
+	return uint64(key)
+}
+
+func equalInt(left int, right int) bool {
+	return left == right
+}
+
+// verify checks the Robin Hood properties of the table.
+func verify[K any, V any](t *testing.T, table *table[K, V]) {
+	t.Helper()
+	size, sum := 0, 0
+	for i, s := range table.slots {
+		if s.distance == 0 {
+			continue
+		}
+		size++
+		if (table.bucket(s.hash)+int(s.distance)-1)&table.mask() != i {
+			t.Fatal("slot", i, "has a wrong distance")
+		}
+		// the previous slot holds an element of the same or an earlier bucket.
+		if s.distance > 1 && table.slots[(i-1)&table.mask()].distance < s.distance-1 {
+			t.Fatal("slot", i, "is not sorted by bucket")
+		}
+	}
+	for n := range table.slots {
+		sum += table.bucketSize(n)
+	}
+	same(t, size, table.size)
+	same(t, sum, table.size)
+	if float64(table.size) > float64(len(table.slots))*table.maxLoadFactor {
+		t.Fatal("load factor exceeded")
+	}
+}
+
+func TestHashMapBasicFunction(t *testing.T) {
+	m := New[[]byte, int](hashBytes, bytes.Equal)
+	same(t, m.Empty(), true)
+	same(t, m.BucketCount(), 0)
+	same(t, m.Bucket([]byte("a")), -1)
+	same(t, m.LoadFactor(), 0.0)
+	same(t, m.At([]byte("a")), (*int)(nil))
+	same(t, m.Erase([]byte("a")), false)
+
+	same(t, m.Insert([]byte("a"), 1), true)
+	same(t, m.Insert([]byte("b"), 2), true)
+	same(t, m.Insert([]byte("a"), 3), false)
+	same(t, *m.At([]byte("a")), 1) // not modified
+	same(t, m.InsertOrAssign([]byte("a"), 3), false)
+	same(t, m.InsertOrAssign([]byte("c"), 4), true)
+	*m.At([]byte("b")) = 5
+	value, ok := m.Get([]byte("b"))
+	same(t, value, 5)
+	same(t, ok, true)
+	_, ok = m.Get([]byte("d"))
+	same(t, ok, false)
+	same(t, m.Contains([]byte("c")), true)
+	same(t, m.Size(), 3)
+	same(t, m.BucketCount(), minBuckets)
+	same(t, m.LoadFactor(), 3.0/minBuckets)
+	same(t, m.BucketSize(m.Bucket([]byte("a"))) >= 1, true)
+	same(t, m.BucketSize(-1), 0)
+	same(t, m.BucketSize(minBuckets), 0)
+
+	values := make(map[string]int)
+	for k, v := range m.All() {
+		values[string(k)] = v
+	}
+	same(t, values, map[string]int{"a": 3, "b": 5, "c": 4})
+	same(t, len(slices.Collect(m.Keys())), 3)
+	same(t, slices.Sorted(m.Values()), []int{3, 4, 5})
+
+	same(t, m.Erase([]byte("a")), true)
+	same(t, m.Erase([]byte("a")), false)
+	same(t, m.Size(), 2)
+	verify(t, &m.table)
+
+	m.Clear()
+	same(t, m.Empty(), true)
+	same(t, m.BucketCount(), minBuckets)
+	m.Rehash(0)
+	same(t, m.BucketCount(), 0)
+}
+
+func TestHashMapRandom(t *testing.T) {
+	// a weak hash makes many collisions.
+	weak := func(key int) uint64 {
+		return uint64(key % 64)
+	}
+	for _, hash := range []func(int) uint64{hashInt, weak} {
+		const N = 20000
+		m := New[int, int](hash, equalInt)
+		expect := make(map[int]int)
+		for i := 0; i < N; i++ {
+			k := rand.Intn(N / 4)
+			if rand.Intn(3) == 0 {
+				_, ok := expect[k]
+				same(t, m.Erase(k), ok)
+				delete(expect, k)
+			} else {
+				_, ok := expect[k]
+				same(t, m.InsertOrAssign(k, i), !ok)
+				expect[k] = i
+			}
+		}
+		verify(t, &m.table)
+		same(t, maps.Collect(m.All()), expect)
+		for k, v := range expect {
+			same(t, *m.At(k), v)
+		}
+	}
+}
+
+func TestRehash(t *testing.T) {
+	m := New[int, string](hashInt, equalInt)
+	m.Reserve(1000)
+	buckets := m.BucketCount()
+	same(t, float64(buckets)*m.MaxLoadFactor() >= 1000, true)
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, strconv.Itoa(i))
+	}
+	same(t, m.BucketCount(), buckets) // no rehash
+	verify(t, &m.table)
+
+	m.Rehash(5000)
+	same(t, m.BucketCount(), 8192)
+	verify(t, &m.table)
+	m.Rehash(0)
+	same(t, m.BucketCount(), buckets)
+	for i := 0; i < 900; i++ {
+		m.Erase(i)
+	}
+	m.Rehash(0)
+	same(t, m.BucketCount(), 128)
+	verify(t, &m.table)
+
+	m.SetMaxLoadFactor(0.5)
+	same(t, m.MaxLoadFactor(), 0.5)
+	same(t, m.BucketCount(), 256)
+	verify(t, &m.table)
+	for i := 900; i < 1000; i++ {
+		same(t, *m.At(i), strconv.Itoa(i))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SetMaxLoadFactor(1) must panic")
+		}
+	}()
+	m.SetMaxLoadFactor(1)
+}
+
+func TestHashSet(t *testing.T) {
+	type point struct {
+		name   string
+		coords []int
+	}
+	s := NewSet(func(p point) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		h.WriteString(p.name)
+		for _, c := range p.coords {
+			h.WriteString(strconv.Itoa(c))
+			h.WriteByte(',')
+		}
+		return h.Sum64()
+	}, func(left point, right point) bool {
+		return left.name == right.name && slices.Equal(left.coords, right.coords)
+	})
+
+	for i := 0; i < 100; i++ {
+		same(t, s.Insert(point{fmt.Sprint(i % 10), []int{i, i * i}}), true)
+	}
+	same(t, s.Insert(point{"3", []int{3, 9}}), false)
+	same(t, s.Contains(point{"3", []int{3, 9}}), true)
+	same(t, s.Contains(point{"4", []int{3, 9}}), false)
+	same(t, s.Size(), 100)
+	same(t, len(slices.Collect(s.All())), 100)
+	same(t, s.Erase(point{"3", []int{3, 9}}), true)
+	same(t, s.Erase(point{"3", []int{3, 9}}), false)
+	verify(t, &s.table)
+
+	sum := 0
+	for n := 0; n < s.BucketCount(); n++ {
+		sum += s.BucketSize(n)
+	}
+	same(t, sum, 99)
+	same(t, s.BucketSize(s.Bucket(point{"5", []int{5, 25}})) >= 1, true)
+
+	s.Reserve(1000)
+	same(t, s.LoadFactor() < 0.1, true)
+	s.Rehash(0)
+	s.SetMaxLoadFactor(0.9)
+	same(t, s.MaxLoadFactor(), 0.9)
+	verify(t, &s.table)
+	s.Clear()
+	same(t, s.Empty(), true)
+}
+
+func BenchmarkHashMap(b *testing.B) {
+	const N = 100000
+	keys := make([]int, N)
+	for i := range keys {
+		keys[i] = rand.Int()
+	}
+	hash := func(key int) uint64 {
+		// splitmix64
+		h := uint64(key) + 0x9e3779b97f4a7c15
+		h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+		h = (h ^ h>>27) * 0x94d049bb133111eb
+		return h ^ h>>31
+	}
+
+	b.Run("HashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := New[int, int](hash, equalInt)
+			for _, k := range keys {
+				m.Insert(k, k)
+			}
+			for _, k := range keys {
+				m.At(k)
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := make(map[int]int)
+			for _, k := range keys {
+				m[k] = k
+			}
+			for _, k := range keys {
+				_ = m[k]
+			}
+		}
+	})
+}
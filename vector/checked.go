@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package vector
+
+import (
+	"fmt"
+
+	"github.com/GitSteve1025/containers"
+)
+
+// Errors returned by the checked methods, they are the errors of package containers.
+var (
+	ErrOutOfRange = containers.ErrOutOfRange
+	ErrEmpty      = containers.ErrEmpty
+)
+
+// outOfRange returns an error for index pos, which is not in [0, size).
+func outOfRange(pos int, size int) error {
+	return fmt.Errorf("vector: index %d out of range [0, %d): %w", pos, size, ErrOutOfRange)
+}
+
+// AtE is like At, but returns an error wrapping ErrOutOfRange if pos is out of range.
+func (vec *Vector[T]) AtE(pos int) (*T, error) {
+	if 0 <= pos && pos < len(*vec) {
+		return &(*vec)[pos], nil
+	}
+	return nil, outOfRange(pos, len(*vec))
+}
+
+// FrontE is like Front, but returns an error wrapping ErrEmpty if the vector is empty.
+func (vec *Vector[T]) FrontE() (*T, error) {
+	if len(*vec) > 0 {
+		return &(*vec)[0], nil
+	}
+	return nil, fmt.Errorf("vector: front: %w", ErrEmpty)
+}
+
+// BackE is like Back, but returns an error wrapping ErrEmpty if the vector is empty.
+func (vec *Vector[T]) BackE() (*T, error) {
+	if len(*vec) > 0 {
+		return &(*vec)[len(*vec)-1], nil
+	}
+	return nil, fmt.Errorf("vector: back: %w", ErrEmpty)
+}
+
+// PopBackE is like PopBack, but returns an error wrapping ErrEmpty if the vector is empty.
+func (vec *Vector[T]) PopBackE() (value T, err error) {
+	if len(*vec) > 0 {
+		return vec.PopBack(), nil
+	}
+	return value, fmt.Errorf("vector: pop back: %w", ErrEmpty)
+}
+
+// InsertE is like Insert, but returns an error wrapping ErrOutOfRange if pos < 0 or pos > len(*vec).
+func (vec *Vector[T]) InsertE(pos int, val T) error {
+	if 0 <= pos && pos <= len(*vec) {
+		vec.Insert(pos, val)
+		return nil
+	}
+	return outOfRange(pos, len(*vec)+1)
+}
+
+// InsertRangeE is like InsertRange, but returns an error wrapping ErrOutOfRange if pos < 0 or pos > len(*vec).
+func (vec *Vector[T]) InsertRangeE(pos int, vals ...T) error {
+	if 0 <= pos && pos <= len(*vec) {
+		vec.InsertRange(pos, vals...)
+		return nil
+	}
+	return outOfRange(pos, len(*vec)+1)
+}
+
+// InsertNE is like InsertN, but returns an error wrapping ErrOutOfRange if pos < 0, pos > len(*vec) or n < 0.
+func (vec *Vector[T]) InsertNE(pos int, n int, val T) error {
+	if n < 0 {
+		return fmt.Errorf("vector: insert count %d is negative: %w", n, ErrOutOfRange)
+	}
+	if 0 <= pos && pos <= len(*vec) {
+		vec.InsertN(pos, n, val)
+		return nil
+	}
+	return outOfRange(pos, len(*vec)+1)
+}
+
+// EraseE is like Erase, but returns an error wrapping ErrOutOfRange if pos is out of range.
+func (vec *Vector[T]) EraseE(pos int) (value T, err error) {
+	if 0 <= pos && pos < len(*vec) {
+		return vec.Erase(pos), nil
+	}
+	return value, outOfRange(pos, len(*vec))
+}
+
+// EraseRangeE is like EraseRange, but returns an error wrapping ErrOutOfRange
+// if first < 0, last > len(*vec) or first > last.
+func (vec *Vector[T]) EraseRangeE(first int, last int) error {
+	if 0 <= first && first <= last && last <= len(*vec) {
+		vec.EraseRange(first, last)
+		return nil
+	}
+	return fmt.Errorf("vector: range [%d, %d) out of range [0, %d]: %w", first, last, len(*vec), ErrOutOfRange)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package vector
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// state is the backing array and the size of a vector before a mutation.
+type state[T any] struct {
+	data     *T
+	size     int
+	capacity int
+}
+
+// snapshot returns the state of vec, to be passed to check after the mutation.
+func (vec *Vector[T]) snapshot() state[T] {
+	return state[T]{unsafe.SliceData(*vec), len(*vec), cap(*vec)}
+}
+
+// check is called after every mutation with the state before it, when the containers_debug build tag is set.
+// It panics with a dump of the vector if the size did not change by grow,
+// or if the elements were moved although they fitted in the capacity of before.
+//
+// The check does not detect stale references: Go cannot tell them from valid ones, so a reference
+// returned by Front, Back or At before a mutation that grows the vector past its capacity
+// silently refers to the old backing array. It only asserts that the mutations which fit
+// keep the backing array, and with it the references, as the methods of Vector promise.
+func (vec *Vector[T]) check(before state[T], grow int) {
+	if len(*vec) != before.size+grow {
+		vec.fail(fmt.Sprintf("the size changed from %d to %d, want %d", before.size, len(*vec), before.size+grow), before)
+	}
+	if before.size+grow <= before.capacity && before.capacity > 0 && unsafe.SliceData(*vec) != before.data {
+		vec.fail("the elements were moved although they fit in the capacity, references to them are stale", before)
+	}
+}
+
+// fail panics with reason and a dump of the vector.
+func (vec *Vector[T]) fail(reason string, before state[T]) {
+	const limit = 32
+	values := fmt.Sprint((*vec)[:min(len(*vec), limit)])
+	if len(*vec) > limit {
+		values += " ..."
+	}
+	panic(fmt.Sprintf("vector: invariant violated: %s\nbefore: data %p, size %d, capacity %d\nafter: data %p, size %d, capacity %d\nvalues: %s",
+		reason, before.data, before.size, before.capacity, unsafe.SliceData(*vec), len(*vec), cap(*vec), values))
+}
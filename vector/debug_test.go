@@ -0,0 +1,48 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build containers_debug
+
+package vector
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// mustPanic runs f and checks that it panics with a message containing want.
+func mustPanic(t *testing.T, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, want) {
+			t.Fatalf("got panic %q, want a panic containing %q", msg, want)
+		}
+	}()
+	f()
+}
+
+func TestDebugCheck(t *testing.T) {
+	vec := NewWithData(1, 2, 3)
+	vec.Reserve(10)
+	front := vec.Front()
+	vec.PushBack(4)
+	vec.InsertRange(1, 5, 6)
+	vec.Erase(0)
+	if front != vec.Front() {
+		t.Fatal("the elements were moved")
+	}
+
+	// a mutation that copies the elements although they fit.
+	before := vec.snapshot()
+	*vec = slices.Clone(*vec)
+	mustPanic(t, "references to them are stale", func() { vec.check(before, 0) })
+
+	// a mutation that does not change the size as it should.
+	before = vec.snapshot()
+	*vec = (*vec)[:2]
+	mustPanic(t, "want 4", func() { vec.check(before, -1) })
+}
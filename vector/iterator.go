@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+package vector
+
+// Iterator is a random access iterator over a vector, it is a position in the vector.
+// It refers to the vector rather than to its backing array, so it stays valid when the vector grows,
+// but it keeps its index when elements are inserted or erased before it.
+// Iterators are values: the methods return new iterators and never modify the receiver.
+type Iterator[T any] struct {
+	vec *Vector[T]
+	pos int
+}
+
+// Begin returns an iterator to the first element of the vector.
+func (vec *Vector[T]) Begin() Iterator[T] {
+	return Iterator[T]{vec, 0}
+}
+
+// End returns an iterator past the last element of the vector.
+func (vec *Vector[T]) End() Iterator[T] {
+	return Iterator[T]{vec, len(*vec)}
+}
+
+// Index returns the position of the iterator in the vector.
+func (it Iterator[T]) Index() int {
+	return it.pos
+}
+
+// Deref returns a reference to the element the iterator points to.
+// Deref will return nil if the iterator is out of range, such as End().
+func (it Iterator[T]) Deref() *T {
+	return it.vec.At(it.pos)
+}
+
+// Next returns an iterator to the next element.
+func (it Iterator[T]) Next() Iterator[T] {
+	return Iterator[T]{it.vec, it.pos + 1}
+}
+
+// Prev returns an iterator to the previous element.
+func (it Iterator[T]) Prev() Iterator[T] {
+	return Iterator[T]{it.vec, it.pos - 1}
+}
+
+// Advance returns an iterator n elements after it, or -n elements before it if n is negative.
+func (it Iterator[T]) Advance(n int) Iterator[T] {
+	return Iterator[T]{it.vec, it.pos + n}
+}
+
+// Distance returns the number of elements from it to other, which is negative if other comes before it.
+// Both iterators must belong to the same vector.
+func (it Iterator[T]) Distance(other Iterator[T]) int {
+	return other.pos - it.pos
+}
+
+// Equal returns true if it and other point to the same position of the same vector.
+func (it Iterator[T]) Equal(other Iterator[T]) bool {
+	return it == other
+}
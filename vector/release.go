@@ -0,0 +1,16 @@
+// Copyright (c) 2024 Tecy.
+// This file is licensed under the MIT License.
+// See the LICENSE file in the project root for more information.
+
+//go:build !containers_debug
+
+package vector
+
+// state is empty without the containers_debug build tag.
+type state[T any] struct{}
+
+// snapshot does nothing without the containers_debug build tag, the call is inlined away.
+func (vec *Vector[T]) snapshot() state[T] { return state[T]{} }
+
+// check does nothing without the containers_debug build tag, the call is inlined away.
+func (vec *Vector[T]) check(before state[T], grow int) {}
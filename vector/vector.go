@@ -4,6 +4,11 @@
 
 package vector
 
+import (
+	"iter"
+	"slices"
+)
+
 type Vector[T any] []T
 
 // New creates a new empty Vector[T].
@@ -22,6 +27,14 @@ func NewWithData[T any](data ...T) *Vector[T] {
 	return vec
 }
 
+// Collect creates a Vector[T] with the values of seq.
+// Values will be placed in the order in which seq yields them.
+func Collect[T any](seq iter.Seq[T]) *Vector[T] {
+	vec := New[T]()
+	vec.AppendSeq(seq)
+	return vec
+}
+
 // Size returns the number of elements in the vector.
 func (vec *Vector[T]) Size() int {
 	return len(*vec)
@@ -74,7 +87,18 @@ func (vec *Vector[T]) Assign(n int, val T) {
 
 // PushBack adds data to the end of the vector.
 func (vec *Vector[T]) PushBack(val T) {
+	before := vec.snapshot()
 	*vec = append(*vec, val)
+	vec.check(before, 1)
+}
+
+// AppendSeq adds the values of seq to the end of the vector.
+func (vec *Vector[T]) AppendSeq(seq iter.Seq[T]) {
+	for x := range seq {
+		before := vec.snapshot()
+		*vec = append(*vec, x)
+		vec.check(before, 1)
+	}
 }
 
 // PopBack removes last element and returns the value of the element.
@@ -82,8 +106,10 @@ func (vec *Vector[T]) PushBack(val T) {
 // PopBack returns the default value of T when vec is empty.
 func (vec *Vector[T]) PopBack() (value T) {
 	if len(*vec) > 0 {
+		before := vec.snapshot()
 		temp := (*vec)[len(*vec)-1]
 		*vec = (*vec)[:len(*vec)-1]
+		vec.check(before, -1)
 		return temp
 	}
 	return
@@ -93,9 +119,11 @@ func (vec *Vector[T]) PopBack() (value T) {
 // If pos < 0 or pos > len(*vec), vec will not be modified.
 func (vec *Vector[T]) Insert(pos int, val T) {
 	if 0 <= pos && pos <= len(*vec) {
+		before := vec.snapshot()
 		*vec = append(*vec, val)
 		copy((*vec)[pos+1:], (*vec)[pos:])
 		(*vec)[pos] = val
+		vec.check(before, 1)
 	}
 }
 
@@ -103,14 +131,63 @@ func (vec *Vector[T]) Insert(pos int, val T) {
 // When pos is out of range, vec will not be modified and erase will return the default value of T.
 func (vec *Vector[T]) Erase(pos int) (value T) {
 	if 0 <= pos && pos < len(*vec) {
+		before := vec.snapshot()
 		temp := (*vec)[pos]
 		copy((*vec)[pos:], (*vec)[pos+1:])
 		*vec = (*vec)[:len(*vec)-1]
+		vec.check(before, -1)
 		return temp
 	}
 	return
 }
 
+// InsertRange inserts vals into vector before specified position, keeping their order.
+// If pos < 0 or pos > len(*vec), vec will not be modified.
+func (vec *Vector[T]) InsertRange(pos int, vals ...T) {
+	if 0 <= pos && pos <= len(*vec) {
+		before := vec.snapshot()
+		*vec = slices.Insert(*vec, pos, vals...)
+		vec.check(before, len(vals))
+	}
+}
+
+// InsertN inserts n copies of val into vector before specified position.
+// If pos < 0, pos > len(*vec) or n < 0, vec will not be modified.
+func (vec *Vector[T]) InsertN(pos int, n int, val T) {
+	if 0 <= pos && pos <= len(*vec) && n >= 0 {
+		before, size := vec.snapshot(), len(*vec)
+		*vec = slices.Grow(*vec, n)[:size+n]
+		copy((*vec)[pos+n:], (*vec)[pos:size])
+		for i := pos; i < pos+n; i++ {
+			(*vec)[i] = val
+		}
+		vec.check(before, n)
+	}
+}
+
+// EraseRange removes the elements in [first, last).
+// If first < 0, last > len(*vec) or first > last, vec will not be modified.
+func (vec *Vector[T]) EraseRange(first int, last int) {
+	if 0 <= first && first <= last && last <= len(*vec) {
+		before := vec.snapshot()
+		*vec = slices.Delete(*vec, first, last)
+		vec.check(before, first-last)
+	}
+}
+
+// Reserve increases Capacity() to at least n without changing Size().
+// If n <= Capacity(), vec will not be modified.
+func (vec *Vector[T]) Reserve(n int) {
+	if n > cap(*vec) {
+		*vec = slices.Grow(*vec, n-len(*vec))
+	}
+}
+
+// Swap exchanges the contents of vec and other.
+func (vec *Vector[T]) Swap(other *Vector[T]) {
+	*vec, *other = *other, *vec
+}
+
 // At returns a reference to the data at position pos.
 // If pos is out of range, At will return nil.
 func (vec *Vector[T]) At(pos int) *T {
@@ -130,5 +207,40 @@ func (vec *Vector[T]) ShrinkToFit() {
 
 // Clear clears Vector[T]
 func (vec *Vector[T]) Clear() {
+	before, n := vec.snapshot(), len(*vec)
 	*vec = (*vec)[:0]
+	vec.check(before, -n)
+}
+
+// All returns an iterator over index-value pairs of the vector in order.
+func (vec *Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, x := range *vec {
+			if !yield(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the vector in order.
+func (vec *Vector[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range *vec {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs of the vector, traversing it backward with descending indices.
+func (vec *Vector[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(*vec) - 1; i >= 0; i-- {
+			if !yield(i, (*vec)[i]) {
+				return
+			}
+		}
+	}
 }
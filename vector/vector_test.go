@@ -5,8 +5,12 @@
 package vector
 
 import (
+	"errors"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/GitSteve1025/containers"
 )
 
 func TestMake(t *testing.T) {
@@ -195,3 +199,193 @@ func TestNewWithDataAndClear(t *testing.T) {
 	vec.Assign(1, 1)
 	vec.Clear()
 }
+
+func TestIterator(t *testing.T) {
+	vec := NewWithData(1, 2, 3, 4, 5)
+
+	var index []int
+	var values []int
+	for i, x := range vec.All() {
+		index = append(index, i)
+		values = append(values, x)
+	}
+	if !slices.Equal(index, []int{0, 1, 2, 3, 4}) || !slices.Equal(values, []int{1, 2, 3, 4, 5}) {
+		t.Error("All is invalid", index, values)
+	}
+
+	if !slices.Equal(slices.Collect(vec.Values()), []int{1, 2, 3, 4, 5}) {
+		t.Error("Values is invalid")
+	}
+
+	index = index[:0]
+	values = values[:0]
+	for i, x := range vec.Backward() {
+		if i == 1 {
+			break
+		}
+		index = append(index, i)
+		values = append(values, x)
+	}
+	if !slices.Equal(index, []int{4, 3, 2}) || !slices.Equal(values, []int{5, 4, 3}) {
+		t.Error("Backward is invalid", index, values)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	vec := Collect(slices.Values([]int{1, 2, 3}))
+	if !slices.Equal(*vec, []int{1, 2, 3}) {
+		t.Error("Collect is invalid", *vec)
+	}
+
+	vec.AppendSeq(NewWithData(4, 5).Values())
+	if !slices.Equal(*vec, []int{1, 2, 3, 4, 5}) {
+		t.Error("AppendSeq is invalid", *vec)
+	}
+
+	vec = Collect(slices.Values([]int(nil)))
+	if vec == nil || !vec.Empty() {
+		t.Error("Collect of empty seq is invalid")
+	}
+}
+
+func TestRangeFunctions(t *testing.T) {
+	vec := NewWithData(1, 2, 3)
+	vec.InsertRange(1, 7, 8, 9)
+	vec.InsertRange(6, 10)
+	vec.InsertRange(0)
+	vec.InsertRange(-1, 0)
+	vec.InsertRange(8, 0)
+	if !slices.Equal(*vec, []int{1, 7, 8, 9, 2, 3, 10}) {
+		t.Error("InsertRange is invalid", *vec)
+	}
+
+	vec.InsertN(0, 2, 5)
+	vec.InsertN(vec.Size(), 1, 6)
+	vec.InsertN(3, 0, 4)
+	vec.InsertN(1, -1, 4)
+	vec.InsertN(100, 1, 4)
+	if !slices.Equal(*vec, []int{5, 5, 1, 7, 8, 9, 2, 3, 10, 6}) {
+		t.Error("InsertN is invalid", *vec)
+	}
+
+	vec.EraseRange(2, 6)
+	vec.EraseRange(0, 0)
+	vec.EraseRange(3, 2)
+	vec.EraseRange(-1, 2)
+	vec.EraseRange(5, 7)
+	if !slices.Equal(*vec, []int{5, 5, 2, 3, 10, 6}) {
+		t.Error("EraseRange is invalid", *vec)
+	}
+	vec.EraseRange(4, 6)
+	if !slices.Equal(*vec, []int{5, 5, 2, 3}) || (*vec)[:6][4] != 0 {
+		t.Error("EraseRange at the end is invalid", *vec)
+	}
+
+	vec.Reserve(100)
+	if vec.Capacity() < 100 || !slices.Equal(*vec, []int{5, 5, 2, 3}) {
+		t.Error("Reserve is invalid", vec.Capacity(), *vec)
+	}
+	capacity := vec.Capacity()
+	vec.Reserve(10)
+	if vec.Capacity() != capacity {
+		t.Error("Reserve shrinks the vector")
+	}
+
+	other := NewWithData(1)
+	vec.Swap(other)
+	if !slices.Equal(*vec, []int{1}) || !slices.Equal(*other, []int{5, 5, 2, 3}) || other.Capacity() != capacity {
+		t.Error("Swap is invalid", *vec, *other)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	vec := New[int]()
+	if _, err := vec.FrontE(); !errors.Is(err, ErrEmpty) {
+		t.Error("FrontE of an empty vector is invalid", err)
+	}
+	if _, err := vec.BackE(); !errors.Is(err, containers.ErrEmpty) {
+		t.Error("BackE of an empty vector is invalid", err)
+	}
+	if _, err := vec.PopBackE(); !errors.Is(err, ErrEmpty) {
+		t.Error("PopBackE of an empty vector is invalid", err)
+	}
+
+	if err := vec.InsertE(1, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Error("InsertE out of range is invalid", err)
+	}
+	if err := vec.InsertE(0, 1); err != nil {
+		t.Error(err)
+	}
+	if err := vec.InsertRangeE(1, 2, 3, 4); err != nil {
+		t.Error(err)
+	}
+	if err := vec.InsertRangeE(-1, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Error("InsertRangeE out of range is invalid", err)
+	}
+	if x, err := vec.AtE(3); err != nil || *x != 4 {
+		t.Error("AtE is invalid", err)
+	}
+	if _, err := vec.AtE(4); !errors.Is(err, ErrOutOfRange) || err.Error() != "vector: index 4 out of range [0, 4): containers: out of range" {
+		t.Error("AtE out of range is invalid", err)
+	}
+	if x, err := vec.EraseE(0); err != nil || x != 1 {
+		t.Error("EraseE is invalid", err)
+	}
+	if _, err := vec.EraseE(3); !errors.Is(err, ErrOutOfRange) {
+		t.Error("EraseE out of range is invalid", err)
+	}
+	if err := vec.EraseRangeE(2, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Error("EraseRangeE of a reversed range is invalid", err)
+	}
+	if err := vec.EraseRangeE(0, 4); !errors.Is(err, ErrOutOfRange) || err.Error() != "vector: range [0, 4) out of range [0, 3]: containers: out of range" {
+		t.Error("EraseRangeE out of range is invalid", err)
+	}
+	if err := vec.EraseRangeE(0, 1); err != nil {
+		t.Error(err)
+	}
+	front, _ := vec.FrontE()
+	back, _ := vec.BackE()
+	x, err := vec.PopBackE()
+	if *front != 3 || *back != 4 || x != 4 || err != nil || !slices.Equal(*vec, []int{3}) {
+		t.Error("checked functions are invalid", *vec)
+	}
+
+	if err := vec.InsertNE(0, -1, 7); !errors.Is(err, ErrOutOfRange) {
+		t.Error("InsertNE with a negative count is invalid", err)
+	}
+	if err := vec.InsertNE(2, 1, 7); !errors.Is(err, ErrOutOfRange) {
+		t.Error("InsertNE out of range is invalid", err)
+	}
+	if err := vec.InsertNE(1, 2, 7); err != nil || !slices.Equal(*vec, []int{3, 7, 7}) {
+		t.Error("InsertNE is invalid", err, *vec)
+	}
+}
+
+func TestBeginEnd(t *testing.T) {
+	vec := NewWithData(1, 2, 3)
+	begin, end := vec.Begin(), vec.End()
+	if begin.Distance(end) != 3 || end.Distance(begin) != -3 || end.Index() != 3 {
+		t.Error("Distance is invalid", begin.Distance(end))
+	}
+	if end.Deref() != nil || begin.Prev().Deref() != nil {
+		t.Error("Deref out of range must be nil")
+	}
+	if !begin.Advance(3).Equal(end) || !end.Advance(-1).Equal(begin.Next().Next()) || !end.Prev().Next().Equal(end) {
+		t.Error("Advance is invalid")
+	}
+	var values []int
+	for it := begin; !it.Equal(end); it = it.Next() {
+		values = append(values, *it.Deref())
+	}
+	if !slices.Equal(values, []int{1, 2, 3}) {
+		t.Error("iteration is invalid", values)
+	}
+
+	// the iterators stay valid when the vector grows.
+	vec.ShrinkToFit()
+	vec.PushBack(4)
+	*begin.Next().Deref() = 20
+	if !slices.Equal(*vec, []int{1, 20, 3, 4}) || *end.Deref() != 4 {
+		t.Error("iterator after growth is invalid", *vec)
+	}
+}